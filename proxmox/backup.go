@@ -0,0 +1,289 @@
+package proxmox
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Options for creating a backup with vzdump.
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/vzdump
+type BackupOptions struct {
+	BandwidthLimit         *uint                        `json:"bwlimit,omitempty"` // KiB/s, 0 is unlimited
+	Compression            BackupCompression            `json:"compress,omitempty"`
+	ExcludePaths           []string                     `json:"exclude_paths,omitempty"`
+	Fleecing               *BackupFleecing              `json:"fleecing,omitempty"`
+	Mode                   BackupMode                   `json:"mode,omitempty"`
+	NotesTemplate          string                       `json:"notes_template,omitempty"` // e.g. "{{guestname}} {{vmid}}"
+	PbsChangeDetectionMode BackupPbsChangeDetectionMode `json:"pbs_change_detection_mode,omitempty"`
+	Protected              *bool                        `json:"protected,omitempty"`
+	Storage                string                       `json:"storage,omitempty"` // when empty the default storage of the node is used
+}
+
+const (
+	BackupOptions_Error_ExcludePathEmpty string = "exclude path may not be empty"
+	BackupOptions_Error_NoGuests         string = "at least one guest should be specified"
+	BackupOptions_Error_PoolEmpty        string = "pool has no guests to backup"
+)
+
+func (options BackupOptions) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{}
+	if options.BandwidthLimit != nil {
+		params["bwlimit"] = int(*options.BandwidthLimit)
+	}
+	if options.Compression != "" {
+		params["compress"] = string(options.Compression)
+	}
+	if len(options.ExcludePaths) > 0 {
+		params["exclude-path"] = options.ExcludePaths
+	}
+	if options.Fleecing != nil {
+		params["fleecing"] = options.Fleecing.String()
+	}
+	if options.Mode != "" {
+		params["mode"] = string(options.Mode)
+	}
+	if options.NotesTemplate != "" {
+		params["notes-template"] = options.NotesTemplate
+	}
+	if options.PbsChangeDetectionMode != "" {
+		params["pbs-change-detection-mode"] = string(options.PbsChangeDetectionMode)
+	}
+	if options.Protected != nil {
+		params["protected"] = *options.Protected
+	}
+	if options.Storage != "" {
+		params["storage"] = options.Storage
+	}
+	return params
+}
+
+func (options BackupOptions) Validate() error {
+	if err := options.Compression.Validate(); err != nil {
+		return err
+	}
+	for _, e := range options.ExcludePaths {
+		if e == "" {
+			return errors.New(BackupOptions_Error_ExcludePathEmpty)
+		}
+	}
+	if options.Fleecing != nil {
+		if err := options.Fleecing.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := options.Mode.Validate(); err != nil {
+		return err
+	}
+	return options.PbsChangeDetectionMode.Validate()
+}
+
+// Backs up the guests and returns the volume ids of the created backups, e.g. "local:backup/vzdump-qemu-100-2024_01_01-00_00_00.vma.zst".
+// A backup task is started for every node the guests are on.
+func (options BackupOptions) Backup(c *Client, guests ...*VmRef) ([]string, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if len(guests) == 0 {
+		return nil, errors.New(BackupOptions_Error_NoGuests)
+	}
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	for _, e := range guests {
+		if err := c.CheckVmRef(e); err != nil {
+			return nil, err
+		}
+	}
+	return options.backup(c, backupGuestsPerNode(guests))
+}
+
+// Backs up all guests in the pool and returns the volume ids of the created backups.
+// A backup task is started for every node the guests are on.
+func (options BackupOptions) BackupPool(c *Client, pool PoolName) ([]string, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if err := pool.Validate(); err != nil {
+		return nil, err
+	}
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	resources, err := ListGuests(c)
+	if err != nil {
+		return nil, err
+	}
+	guests := make([]*VmRef, 0)
+	for _, e := range resources {
+		if e.Pool == pool {
+			vmr := NewVmRef(int(e.Id))
+			vmr.SetNode(e.Node)
+			vmr.SetVmType(string(e.Type))
+			guests = append(guests, vmr)
+		}
+	}
+	if len(guests) == 0 {
+		return nil, errors.New(BackupOptions_Error_PoolEmpty)
+	}
+	return options.backup(c, backupGuestsPerNode(guests))
+}
+
+func (options BackupOptions) backup(c *Client, guestsPerNode map[string][]int) ([]string, error) {
+	nodes := make([]string, 0, len(guestsPerNode))
+	for node := range guestsPerNode {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	volumes := make([]string, 0)
+	for _, node := range nodes {
+		storage := options.Storage
+		if storage == "" {
+			var err error
+			if storage, err = backupDefaultStorage(c, node); err != nil {
+				return volumes, err
+			}
+		}
+		ids := make([]string, len(guestsPerNode[node]))
+		for i, e := range guestsPerNode[node] {
+			ids[i] = strconv.Itoa(e)
+		}
+		params := options.mapToApiValues()
+		params["vmid"] = strings.Join(ids, ",")
+		upid, _, err := c.postWithTaskUpid(params, "/nodes/"+node+"/vzdump")
+		if upid != "" {
+			// also read the log when the task failed, as some of the backups may have been created
+			log, logErr := c.getTaskLog(upid)
+			if logErr != nil && err == nil {
+				err = logErr
+			}
+			volumes = append(volumes, backupVolumesFromTaskLog(storage, log)...)
+		}
+		if err != nil {
+			return volumes, err
+		}
+	}
+	return volumes, nil
+}
+
+// Returns the storage vzdump uses on the node when no storage is specified.
+func backupDefaultStorage(c *Client, node string) (string, error) {
+	params, err := c.GetItemConfigMapStringInterface("/nodes/"+node+"/vzdump/defaults", "vzdump", "DEFAULTS")
+	if err != nil {
+		return "", err
+	}
+	if v, isSet := params["storage"]; isSet {
+		return v.(string), nil
+	}
+	return "local", nil
+}
+
+// Groups the ids of the guests by the node they are on, in the order they were specified.
+func backupGuestsPerNode(guests []*VmRef) map[string][]int {
+	guestsPerNode := make(map[string][]int)
+	for _, e := range guests {
+		guestsPerNode[e.node] = append(guestsPerNode[e.node], e.vmId)
+	}
+	return guestsPerNode
+}
+
+var (
+	rxBackupArchive    = regexp.MustCompile(`creating vzdump archive '([^']+)'`)
+	rxBackupArchivePbs = regexp.MustCompile(`creating Proxmox Backup Server archive '([^']+)'`)
+)
+
+// Extracts the volume ids of the created backups from the log of a vzdump task.
+func backupVolumesFromTaskLog(storage string, log []string) []string {
+	volumes := make([]string, 0)
+	for _, line := range log {
+		if match := rxBackupArchive.FindStringSubmatch(line); match != nil {
+			volumes = append(volumes, storage+":backup/"+path.Base(match[1]))
+		} else if match := rxBackupArchivePbs.FindStringSubmatch(line); match != nil {
+			volumes = append(volumes, storage+":backup/"+match[1])
+		}
+	}
+	return volumes
+}
+
+type BackupCompression string
+
+const (
+	BackupCompression_Enabled BackupCompression = "1" // compressed with lzo
+	BackupCompression_Gzip    BackupCompression = "gzip"
+	BackupCompression_Lzo     BackupCompression = "lzo"
+	BackupCompression_None    BackupCompression = "0"
+	BackupCompression_Zstd    BackupCompression = "zstd"
+)
+
+const BackupCompression_Error_Invalid string = "compression should be one of [0, 1, gzip, lzo, zstd]"
+
+func (compression BackupCompression) Validate() error {
+	switch compression {
+	case "", BackupCompression_Enabled, BackupCompression_Gzip, BackupCompression_Lzo, BackupCompression_None, BackupCompression_Zstd:
+		return nil
+	}
+	return errors.New(BackupCompression_Error_Invalid)
+}
+
+// Fleecing uses a temporary image on a fast storage to limit the impact of the backup on the guest's io.
+type BackupFleecing struct {
+	Enabled bool   `json:"enabled"`
+	Storage string `json:"storage,omitempty"`
+}
+
+const BackupFleecing_Error_StorageEmpty string = "fleecing storage should be specified when fleecing is enabled"
+
+func (fleecing BackupFleecing) String() string { // String is for fmt.Stringer.
+	settings := "enabled=" + boolToIntString(fleecing.Enabled)
+	if fleecing.Storage != "" {
+		settings += ",storage=" + fleecing.Storage
+	}
+	return settings
+}
+
+func (fleecing BackupFleecing) Validate() error {
+	if fleecing.Enabled && fleecing.Storage == "" {
+		return errors.New(BackupFleecing_Error_StorageEmpty)
+	}
+	return nil
+}
+
+type BackupMode string
+
+const (
+	BackupMode_Snapshot BackupMode = "snapshot"
+	BackupMode_Stop     BackupMode = "stop"
+	BackupMode_Suspend  BackupMode = "suspend"
+)
+
+const BackupMode_Error_Invalid string = "mode should be one of [snapshot, stop, suspend]"
+
+func (mode BackupMode) Validate() error {
+	switch mode {
+	case "", BackupMode_Snapshot, BackupMode_Stop, BackupMode_Suspend:
+		return nil
+	}
+	return errors.New(BackupMode_Error_Invalid)
+}
+
+// How a Proxmox Backup Server detects which files of a container changed.
+type BackupPbsChangeDetectionMode string
+
+const (
+	BackupPbsChangeDetectionMode_Data     BackupPbsChangeDetectionMode = "data"
+	BackupPbsChangeDetectionMode_Legacy   BackupPbsChangeDetectionMode = "legacy"
+	BackupPbsChangeDetectionMode_Metadata BackupPbsChangeDetectionMode = "metadata"
+)
+
+const BackupPbsChangeDetectionMode_Error_Invalid string = "pbs change detection mode should be one of [data, legacy, metadata]"
+
+func (mode BackupPbsChangeDetectionMode) Validate() error {
+	switch mode {
+	case "", BackupPbsChangeDetectionMode_Data, BackupPbsChangeDetectionMode_Legacy, BackupPbsChangeDetectionMode_Metadata:
+		return nil
+	}
+	return errors.New(BackupPbsChangeDetectionMode_Error_Invalid)
+}
@@ -0,0 +1,163 @@
+package proxmox
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+)
+
+// Options for restoring a backup archive to a new guest, or over an existing guest when Force is set.
+type RestoreOptions struct {
+	BandwidthLimit *uint    `json:"bwlimit,omitempty"`      // KiB/s, 0 is unlimited
+	Force          bool     `json:"force,omitempty"`        // overwrite the existing guest
+	LiveRestore    bool     `json:"live_restore,omitempty"` // start the guest while restoring, only for qemu guests and archives on a Proxmox Backup Server
+	Pool           PoolName `json:"pool,omitempty"`
+	Start          bool     `json:"start,omitempty"` // start the guest after the restore
+	Storage        string   `json:"storage,omitempty"`
+	UniqueMac      bool     `json:"unique,omitempty"` // assign new random mac addresses
+	// Settings of the guest config that differ from the config in the archive, keys are the same as the api, e.g. "memory" or "hostname".
+	Overrides map[string]interface{} `json:"overrides,omitempty"`
+}
+
+const (
+	RestoreOptions_Error_LiveRestoreLxc string = "live restore is only supported for qemu guests"
+	RestoreOptions_Error_LiveRestorePbs string = "live restore is only supported for archives on a Proxmox Backup Server"
+	RestoreOptions_Error_Override       string = "override may not contain the restore option: "
+)
+
+var restoreOptionKeys = []string{"archive", "bwlimit", "force", "live-restore", "ostemplate", "pool", "restore", "start", "storage", "unique", "vmid"}
+
+func (options RestoreOptions) mapToApiValues(archive string, guestType GuestType, vmId int) map[string]interface{} {
+	params := make(map[string]interface{}, len(options.Overrides)+10)
+	for k, v := range options.Overrides {
+		params[k] = v
+	}
+	params["vmid"] = vmId
+	if guestType == GuestLXC {
+		params["ostemplate"] = archive
+		params["restore"] = true
+	} else {
+		params["archive"] = archive
+		if options.LiveRestore {
+			params["live-restore"] = true
+		}
+	}
+	if options.BandwidthLimit != nil {
+		params["bwlimit"] = int(*options.BandwidthLimit)
+	}
+	if options.Force {
+		params["force"] = true
+	}
+	if options.Pool != "" {
+		params["pool"] = string(options.Pool)
+	}
+	if options.Start {
+		params["start"] = true
+	}
+	if options.Storage != "" {
+		params["storage"] = options.Storage
+	}
+	if options.UniqueMac {
+		params["unique"] = true
+	}
+	return params
+}
+
+func (options RestoreOptions) Validate(archive BackupArchive) error {
+	if options.LiveRestore {
+		if archive.GuestType != GuestQemu {
+			return errors.New(RestoreOptions_Error_LiveRestoreLxc)
+		}
+		if !archive.Pbs {
+			return errors.New(RestoreOptions_Error_LiveRestorePbs)
+		}
+	}
+	if options.Pool != "" {
+		if err := options.Pool.Validate(); err != nil {
+			return err
+		}
+	}
+	keys := make([]string, 0, len(options.Overrides))
+	for k := range options.Overrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if inArray(restoreOptionKeys, k) {
+			return errors.New(RestoreOptions_Error_Override + k)
+		}
+	}
+	return nil
+}
+
+// The properties of a backup archive that can be derived from its volume id.
+type BackupArchive struct {
+	GuestType GuestType `json:"type"`
+	Pbs       bool      `json:"pbs"` // the archive is on a Proxmox Backup Server
+}
+
+const BackupArchive_Error_Invalid string = "volume is not a guest backup archive: "
+
+var (
+	rxBackupArchiveFile  = regexp.MustCompile(`^[^:]+:backup/vzdump-(qemu|lxc|openvz)-\d+-[^/]+$`)
+	rxBackupArchivePbsId = regexp.MustCompile(`^[^:]+:backup/(vm|ct)/\d+/[^/]+$`)
+)
+
+// Derives the guest type of the backup archive from its volume id, e.g. "local:backup/vzdump-qemu-100-2024_05_01-10_00_00.vma.zst" or "pbs:backup/ct/101/2024-05-01T10:00:00Z".
+func NewBackupArchive(volumeId string) (BackupArchive, error) {
+	if match := rxBackupArchiveFile.FindStringSubmatch(volumeId); match != nil {
+		if match[1] == "qemu" {
+			return BackupArchive{GuestType: GuestQemu}, nil
+		}
+		return BackupArchive{GuestType: GuestLXC}, nil
+	}
+	if match := rxBackupArchivePbsId.FindStringSubmatch(volumeId); match != nil {
+		if match[1] == "vm" {
+			return BackupArchive{GuestType: GuestQemu, Pbs: true}, nil
+		}
+		return BackupArchive{GuestType: GuestLXC, Pbs: true}, nil
+	}
+	return BackupArchive{}, errors.New(BackupArchive_Error_Invalid + volumeId)
+}
+
+const (
+	RestoreGuest_Error_GuestType string = "guest type of the target does not match the archive, archive type: "
+	RestoreGuest_Error_Node      string = "node of the target should be specified"
+	RestoreGuest_Error_VmId      string = "id of the target should be specified"
+)
+
+// Restores the backup archive (volume id) to the target guest.
+// The guest type of the target is derived from the archive, when it is set it has to match the archive.
+// The target guest should not exist unless options.Force is set.
+func RestoreGuest(c *Client, archive string, target *VmRef, options RestoreOptions) error {
+	if c == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	guestType, err := validateRestoreGuest(archive, target, options)
+	if err != nil {
+		return err
+	}
+	_, err = c.PostWithTask(options.mapToApiValues(archive, guestType, target.vmId), "/nodes/"+target.node+"/"+string(guestType))
+	if err != nil {
+		return err
+	}
+	target.vmType = string(guestType)
+	return nil
+}
+
+func validateRestoreGuest(archive string, target *VmRef, options RestoreOptions) (GuestType, error) {
+	if target == nil || target.vmId <= 0 {
+		return "", errors.New(RestoreGuest_Error_VmId)
+	}
+	if target.node == "" {
+		return "", errors.New(RestoreGuest_Error_Node)
+	}
+	archiveInfo, err := NewBackupArchive(archive)
+	if err != nil {
+		return "", err
+	}
+	if target.vmType != "" && GuestType(target.vmType) != archiveInfo.GuestType {
+		return "", errors.New(RestoreGuest_Error_GuestType + string(archiveInfo.GuestType))
+	}
+	return archiveInfo.GuestType, options.Validate(archiveInfo)
+}
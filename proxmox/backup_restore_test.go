@@ -0,0 +1,171 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewBackupArchive(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		output BackupArchive
+		err    error
+	}{
+		{name: `Qemu file`,
+			input:  "local:backup/vzdump-qemu-100-2024_05_01-10_00_00.vma.zst",
+			output: BackupArchive{GuestType: GuestQemu}},
+		{name: `Lxc file`,
+			input:  "local:backup/vzdump-lxc-101-2024_05_01-10_00_00.tar.zst",
+			output: BackupArchive{GuestType: GuestLXC}},
+		{name: `OpenVZ file`,
+			input:  "local:backup/vzdump-openvz-101-2014_05_01-10_00_00.tar",
+			output: BackupArchive{GuestType: GuestLXC}},
+		{name: `Qemu pbs`,
+			input:  "pbs:backup/vm/100/2024-05-01T10:00:00Z",
+			output: BackupArchive{GuestType: GuestQemu, Pbs: true}},
+		{name: `Lxc pbs`,
+			input:  "pbs:backup/ct/101/2024-05-01T10:00:00Z",
+			output: BackupArchive{GuestType: GuestLXC, Pbs: true}},
+		{name: `Invalid host backup`,
+			input: "pbs:backup/host/pve/2024-05-01T10:00:00Z",
+			err:   errors.New(BackupArchive_Error_Invalid + "pbs:backup/host/pve/2024-05-01T10:00:00Z")},
+		{name: `Invalid iso`,
+			input: "local:iso/debian.iso",
+			err:   errors.New(BackupArchive_Error_Invalid + "local:iso/debian.iso")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			archive, err := NewBackupArchive(test.input)
+			require.Equal(t, test.err, err)
+			require.Equal(t, test.output, archive)
+		})
+	}
+}
+
+func Test_RestoreOptions_mapToApiValues(t *testing.T) {
+	type testInput struct {
+		options   RestoreOptions
+		archive   string
+		guestType GuestType
+		vmId      int
+	}
+	tests := []struct {
+		name   string
+		input  testInput
+		output map[string]interface{}
+	}{
+		{name: `Qemu minimal`,
+			input: testInput{archive: "pbs:backup/vm/100/2024-05-01T10:00:00Z", guestType: GuestQemu, vmId: 200},
+			output: map[string]interface{}{
+				"archive": "pbs:backup/vm/100/2024-05-01T10:00:00Z",
+				"vmid":    200}},
+		{name: `Qemu full`,
+			input: testInput{
+				options: RestoreOptions{
+					BandwidthLimit: util.Pointer(uint(0)),
+					Force:          true,
+					LiveRestore:    true,
+					Pool:           "prod",
+					Start:          true,
+					Storage:        "local-lvm",
+					UniqueMac:      true,
+					Overrides:      map[string]interface{}{"memory": 4096}},
+				archive: "pbs:backup/vm/100/2024-05-01T10:00:00Z", guestType: GuestQemu, vmId: 200},
+			output: map[string]interface{}{
+				"archive":      "pbs:backup/vm/100/2024-05-01T10:00:00Z",
+				"bwlimit":      0,
+				"force":        true,
+				"live-restore": true,
+				"memory":       4096,
+				"pool":         "prod",
+				"start":        true,
+				"storage":      "local-lvm",
+				"unique":       true,
+				"vmid":         200}},
+		{name: `Lxc`,
+			input: testInput{
+				options: RestoreOptions{
+					LiveRestore: true,
+					Storage:     "local-lvm",
+					Overrides:   map[string]interface{}{"hostname": "restored"}},
+				archive: "local:backup/vzdump-lxc-101-2024_05_01-10_00_00.tar.zst", guestType: GuestLXC, vmId: 201},
+			output: map[string]interface{}{
+				"hostname":   "restored",
+				"ostemplate": "local:backup/vzdump-lxc-101-2024_05_01-10_00_00.tar.zst",
+				"restore":    true,
+				"storage":    "local-lvm",
+				"vmid":       201}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.options.mapToApiValues(test.input.archive, test.input.guestType, test.input.vmId))
+		})
+	}
+}
+
+func Test_validateRestoreGuest(t *testing.T) {
+	const (
+		qemuFile = "local:backup/vzdump-qemu-100-2024_05_01-10_00_00.vma.zst"
+		qemuPbs  = "pbs:backup/vm/100/2024-05-01T10:00:00Z"
+		lxcPbs   = "pbs:backup/ct/101/2024-05-01T10:00:00Z"
+	)
+	type testInput struct {
+		archive string
+		target  *VmRef
+		options RestoreOptions
+	}
+	tests := []struct {
+		name   string
+		input  testInput
+		output GuestType
+		err    error
+	}{
+		{name: `Valid qemu`,
+			input:  testInput{archive: qemuFile, target: &VmRef{vmId: 200, node: "pve"}},
+			output: GuestQemu},
+		{name: `Valid qemu live restore`,
+			input:  testInput{archive: qemuPbs, target: &VmRef{vmId: 200, node: "pve", vmType: "qemu"}, options: RestoreOptions{LiveRestore: true}},
+			output: GuestQemu},
+		{name: `Valid lxc overrides`,
+			input:  testInput{archive: lxcPbs, target: &VmRef{vmId: 201, node: "pve"}, options: RestoreOptions{Overrides: map[string]interface{}{"hostname": "test"}}},
+			output: GuestLXC},
+		{name: `Invalid target nil`,
+			input: testInput{archive: qemuFile},
+			err:   errors.New(RestoreGuest_Error_VmId)},
+		{name: `Invalid target id`,
+			input: testInput{archive: qemuFile, target: &VmRef{node: "pve"}},
+			err:   errors.New(RestoreGuest_Error_VmId)},
+		{name: `Invalid target node`,
+			input: testInput{archive: qemuFile, target: &VmRef{vmId: 200}},
+			err:   errors.New(RestoreGuest_Error_Node)},
+		{name: `Invalid archive`,
+			input: testInput{archive: "local:iso/debian.iso", target: &VmRef{vmId: 200, node: "pve"}},
+			err:   errors.New(BackupArchive_Error_Invalid + "local:iso/debian.iso")},
+		{name: `Invalid guest type`,
+			input: testInput{archive: lxcPbs, target: &VmRef{vmId: 200, node: "pve", vmType: "qemu"}},
+			err:   errors.New(RestoreGuest_Error_GuestType + "lxc")},
+		{name: `Invalid live restore lxc`,
+			input:  testInput{archive: lxcPbs, target: &VmRef{vmId: 200, node: "pve"}, options: RestoreOptions{LiveRestore: true}},
+			output: GuestLXC,
+			err:    errors.New(RestoreOptions_Error_LiveRestoreLxc)},
+		{name: `Invalid live restore file`,
+			input:  testInput{archive: qemuFile, target: &VmRef{vmId: 200, node: "pve"}, options: RestoreOptions{LiveRestore: true}},
+			output: GuestQemu,
+			err:    errors.New(RestoreOptions_Error_LiveRestorePbs)},
+		{name: `Invalid override`,
+			input:  testInput{archive: qemuFile, target: &VmRef{vmId: 200, node: "pve"}, options: RestoreOptions{Overrides: map[string]interface{}{"memory": 1024, "storage": "local"}}},
+			output: GuestQemu,
+			err:    errors.New(RestoreOptions_Error_Override + "storage")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			guestType, err := validateRestoreGuest(test.input.archive, test.input.target, test.input.options)
+			require.Equal(t, test.err, err)
+			require.Equal(t, test.output, guestType)
+		})
+	}
+}
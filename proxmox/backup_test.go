@@ -0,0 +1,145 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BackupOptions_mapToApiValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  BackupOptions
+		output map[string]interface{}
+	}{
+		{name: `Empty`,
+			output: map[string]interface{}{}},
+		{name: `Full`,
+			input: BackupOptions{
+				BandwidthLimit:         util.Pointer(uint(10240)),
+				Compression:            BackupCompression_Zstd,
+				ExcludePaths:           []string{"/tmp/?*", "/var/cache"},
+				Fleecing:               &BackupFleecing{Enabled: true, Storage: "local-lvm"},
+				Mode:                   BackupMode_Snapshot,
+				NotesTemplate:          "{{guestname}}",
+				PbsChangeDetectionMode: BackupPbsChangeDetectionMode_Metadata,
+				Protected:              util.Pointer(true),
+				Storage:                "pbs"},
+			output: map[string]interface{}{
+				"bwlimit":                   10240,
+				"compress":                  "zstd",
+				"exclude-path":              []string{"/tmp/?*", "/var/cache"},
+				"fleecing":                  "enabled=1,storage=local-lvm",
+				"mode":                      "snapshot",
+				"notes-template":            "{{guestname}}",
+				"pbs-change-detection-mode": "metadata",
+				"protected":                 true,
+				"storage":                   "pbs"}},
+		{name: `Zero values of pointers`,
+			input: BackupOptions{
+				BandwidthLimit: util.Pointer(uint(0)),
+				Fleecing:       &BackupFleecing{},
+				Protected:      util.Pointer(false)},
+			output: map[string]interface{}{
+				"bwlimit":   0,
+				"fleecing":  "enabled=0",
+				"protected": false}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.mapToApiValues())
+		})
+	}
+}
+
+func Test_BackupOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  BackupOptions
+		output error
+	}{
+		{name: `Valid empty`},
+		{name: `Valid full`,
+			input: BackupOptions{
+				Compression:            BackupCompression_Lzo,
+				ExcludePaths:           []string{"/tmp"},
+				Fleecing:               &BackupFleecing{Enabled: true, Storage: "local"},
+				Mode:                   BackupMode_Stop,
+				PbsChangeDetectionMode: BackupPbsChangeDetectionMode_Legacy}},
+		{name: `Valid Compression 1`,
+			input: BackupOptions{Compression: BackupCompression_Enabled}},
+		{name: `Invalid Compression lz4`,
+			input:  BackupOptions{Compression: "lz4"},
+			output: errors.New(BackupCompression_Error_Invalid)},
+		{name: `Invalid Compression`,
+			input:  BackupOptions{Compression: "bzip2"},
+			output: errors.New(BackupCompression_Error_Invalid)},
+		{name: `Invalid ExcludePaths`,
+			input:  BackupOptions{ExcludePaths: []string{"/tmp", ""}},
+			output: errors.New(BackupOptions_Error_ExcludePathEmpty)},
+		{name: `Invalid Fleecing`,
+			input:  BackupOptions{Fleecing: &BackupFleecing{Enabled: true}},
+			output: errors.New(BackupFleecing_Error_StorageEmpty)},
+		{name: `Invalid Mode`,
+			input:  BackupOptions{Mode: "pause"},
+			output: errors.New(BackupMode_Error_Invalid)},
+		{name: `Invalid PbsChangeDetectionMode`,
+			input:  BackupOptions{PbsChangeDetectionMode: "all"},
+			output: errors.New(BackupPbsChangeDetectionMode_Error_Invalid)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
+
+func Test_backupGuestsPerNode(t *testing.T) {
+	require.Equal(t,
+		map[string][]int{"pve1": {102, 100}, "pve2": {101}},
+		backupGuestsPerNode([]*VmRef{
+			{vmId: 102, node: "pve1"},
+			{vmId: 101, node: "pve2"},
+			{vmId: 100, node: "pve1"}}))
+}
+
+func Test_backupVolumesFromTaskLog(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage string
+		input   []string
+		output  []string
+	}{
+		{name: `Directory storage`,
+			storage: "local",
+			input: []string{
+				"INFO: starting new backup job: vzdump 100 101 --storage local --mode snapshot",
+				"INFO: Starting Backup of VM 100 (qemu)",
+				"INFO: creating vzdump archive '/var/lib/vz/dump/vzdump-qemu-100-2024_05_01-10_00_00.vma.zst'",
+				"INFO: Finished Backup of VM 100 (00:00:10)",
+				"INFO: Starting Backup of VM 101 (lxc)",
+				"INFO: creating vzdump archive '/var/lib/vz/dump/vzdump-lxc-101-2024_05_01-10_00_10.tar.zst'",
+				"INFO: Backup job finished successfully"},
+			output: []string{
+				"local:backup/vzdump-qemu-100-2024_05_01-10_00_00.vma.zst",
+				"local:backup/vzdump-lxc-101-2024_05_01-10_00_10.tar.zst"}},
+		{name: `Proxmox Backup Server`,
+			storage: "pbs",
+			input: []string{
+				"INFO: Starting Backup of VM 100 (qemu)",
+				"INFO: creating Proxmox Backup Server archive 'vm/100/2024-05-01T10:00:00Z'",
+				"INFO: Finished Backup of VM 100 (00:00:10)"},
+			output: []string{"pbs:backup/vm/100/2024-05-01T10:00:00Z"}},
+		{name: `No backups`,
+			storage: "local",
+			input:   []string{"ERROR: Backup of VM 100 failed - unable to find VM '100'"},
+			output:  []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, backupVolumesFromTaskLog(test.storage, test.input))
+		})
+	}
+}
@@ -319,6 +319,7 @@ func (c *Client) GetVmConfig(vmr *VmRef) (vmConfig map[string]interface{}, err e
 	return c.GetItemConfigMapStringInterface("/nodes/"+vmr.node+"/"+vmr.vmType+"/"+strconv.Itoa(vmr.vmId)+"/config", "vm", "CONFIG")
 }
 
+// deprecated use NewStorageStatusFromApi() instead
 func (c *Client) GetStorageStatus(vmr *VmRef, storageName string) (storageStatus map[string]interface{}, err error) {
 	err = c.CheckVmRef(vmr)
 	if err != nil {
@@ -482,6 +483,27 @@ func (c *Client) GetTaskExitstatus(taskUpid string) (exitStatus interface{}, err
 	return
 }
 
+// Reads all lines of the log of the task.
+func (c *Client) getTaskLog(taskUpid string) ([]string, error) {
+	const limit = 500
+	node := rxTaskNode.FindStringSubmatch(taskUpid)[1]
+	lines := make([]string, 0)
+	for start := 0; ; start += limit {
+		list, err := c.GetItemListInterfaceArray(fmt.Sprintf("/nodes/%s/tasks/%s/log?start=%d&limit=%d", node, taskUpid, start, limit))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range list {
+			if line, isSet := e.(map[string]interface{})["t"]; isSet {
+				lines = append(lines, line.(string))
+			}
+		}
+		if len(list) < limit {
+			return lines, nil
+		}
+	}
+}
+
 func (c *Client) StatusChangeVm(vmr *VmRef, params map[string]interface{}, setStatus string) (exitStatus string, err error) {
 	err = c.CheckVmRef(vmr)
 	if err != nil {
@@ -843,6 +865,7 @@ func (c *Client) MoveQemuDisk(vmr *VmRef, disk string, storage string) (exitStat
 }
 
 // MoveQemuDiskToVM - Move a disk to a different VM, using the same storage
+// deprecated use ReassignDisk() instead
 func (c *Client) MoveQemuDiskToVM(vmrSource *VmRef, disk string, vmrTarget *VmRef) (exitStatus interface{}, err error) {
 	reqbody := ParamsToBody(map[string]interface{}{"disk": disk, "target-vmid": vmrTarget.vmId, "delete": true})
 	url := fmt.Sprintf("/nodes/%s/%s/%d/move_disk", vmrSource.node, vmrSource.vmType, vmrSource.vmId)
@@ -1017,6 +1040,7 @@ func (c *Client) DeleteVMDisks(
 }
 
 // VzDump - Create backup
+// deprecated use BackupOptions.Backup() instead
 func (c *Client) VzDump(vmr *VmRef, params map[string]interface{}) (exitStatus interface{}, err error) {
 	err = c.CheckVmRef(vmr)
 	if err != nil {
@@ -1104,6 +1128,7 @@ func (c *Client) QemuAgentPing(vmr *VmRef) (pingRes map[string]interface{}, err
 }
 
 // QemuAgentFileWrite - Writes the given file via guest agent.
+// deprecated use *VmRef.AgentFileWrite() instead
 func (c *Client) QemuAgentFileWrite(vmr *VmRef, params map[string]interface{}) (err error) {
 	err = c.CheckVmRef(vmr)
 	if err != nil {
@@ -1160,6 +1185,7 @@ func (c *Client) QemuAgentExec(vmr *VmRef, params map[string]interface{}) (resul
 }
 
 // GetExecStatus - Gets the status of the given pid started by the guest-agent
+// deprecated use *VmRef.AgentExecStatus() instead
 func (c *Client) GetExecStatus(vmr *VmRef, pid string) (status map[string]interface{}, err error) {
 	err = c.CheckVmRef(vmr)
 	if err != nil {
@@ -2002,6 +2028,96 @@ func (c *Client) UpdateSDNDNS(id string, params map[string]interface{}) error {
 	return c.Put(params, "/cluster/sdn/dns/"+id)
 }
 
+// GetSDNControllers returns a list of all controller definitions in the "data" element of the returned
+// map.
+func (c *Client) GetSDNControllers(pending bool, typeFilter string) (list map[string]interface{}, err error) {
+	url := fmt.Sprintf("/cluster/sdn/controllers?pending=%d", Btoi(pending))
+	if typeFilter != "" {
+		url += fmt.Sprintf("&type=%s", typeFilter)
+	}
+	err = c.GetJsonRetryable(url, &list, 3)
+	return
+}
+
+// CheckSDNControllerExistance returns true if a controller with the provided ID exists, false otherwise.
+func (c *Client) CheckSDNControllerExistance(id string) (existance bool, err error) {
+	list, err := c.GetSDNControllers(true, "")
+	if err != nil {
+		return
+	}
+	existance = ItemInKeyOfArray(list["data"].([]interface{}), "controller", id)
+	return
+}
+
+// GetSDNController returns details about the controller whose name was provided.
+// An error is returned if the controller doesn't exist.
+// The returned map["data"] section can be unmarshalled into a ConfigSDNController struct.
+func (c *Client) GetSDNController(name string) (controller map[string]interface{}, err error) {
+	url := fmt.Sprintf("/cluster/sdn/controllers/%s", name)
+	err = c.GetJsonRetryable(url, &controller, 3)
+	return
+}
+
+// CreateSDNController creates a new SDN controller in the cluster
+func (c *Client) CreateSDNController(params map[string]interface{}) error {
+	return c.Post(params, "/cluster/sdn/controllers")
+}
+
+// DeleteSDNController deletes an existing SDN controller in the cluster
+func (c *Client) DeleteSDNController(name string) error {
+	return c.Delete(fmt.Sprintf("/cluster/sdn/controllers/%s", name))
+}
+
+// UpdateSDNController updates the given controller with the provided parameters
+func (c *Client) UpdateSDNController(id string, params map[string]interface{}) error {
+	return c.Put(params, "/cluster/sdn/controllers/"+id)
+}
+
+// GetSDNIpams returns a list of all IPAM definitions in the "data" element of the returned
+// map.
+func (c *Client) GetSDNIpams(typeFilter string) (list map[string]interface{}, err error) {
+	url := "/cluster/sdn/ipams"
+	if typeFilter != "" {
+		url += fmt.Sprintf("?type=%s", typeFilter)
+	}
+	err = c.GetJsonRetryable(url, &list, 3)
+	return
+}
+
+// CheckSDNIpamExistance returns true if an IPAM with the provided ID exists, false otherwise.
+func (c *Client) CheckSDNIpamExistance(id string) (existance bool, err error) {
+	list, err := c.GetSDNIpams("")
+	if err != nil {
+		return
+	}
+	existance = ItemInKeyOfArray(list["data"].([]interface{}), "ipam", id)
+	return
+}
+
+// GetSDNIpam returns details about the IPAM whose name was provided.
+// An error is returned if the IPAM doesn't exist.
+// The returned map["data"] section can be unmarshalled into a ConfigSDNIpam struct.
+func (c *Client) GetSDNIpam(name string) (ipam map[string]interface{}, err error) {
+	url := fmt.Sprintf("/cluster/sdn/ipams/%s", name)
+	err = c.GetJsonRetryable(url, &ipam, 3)
+	return
+}
+
+// CreateSDNIpam creates a new SDN IPAM in the cluster
+func (c *Client) CreateSDNIpam(params map[string]interface{}) error {
+	return c.Post(params, "/cluster/sdn/ipams")
+}
+
+// DeleteSDNIpam deletes an existing SDN IPAM in the cluster
+func (c *Client) DeleteSDNIpam(name string) error {
+	return c.Delete(fmt.Sprintf("/cluster/sdn/ipams/%s", name))
+}
+
+// UpdateSDNIpam updates the given IPAM with the provided parameters
+func (c *Client) UpdateSDNIpam(id string, params map[string]interface{}) error {
+	return c.Put(params, "/cluster/sdn/ipams/"+id)
+}
+
 // GetSDNZones returns a list of all the SDN zones defined in the cluster.
 func (c *Client) GetSDNZones(pending bool, typeFilter string) (list map[string]interface{}, err error) {
 	url := fmt.Sprintf("/cluster/sdn/zones?pending=%d", Btoi(pending))
@@ -2108,6 +2224,25 @@ func (c *Client) PostWithTask(Params map[string]interface{}, url string) (exitSt
 	return c.CheckTask(resp)
 }
 
+// Makes a POST request and waits on proxmox for the task to complete.
+// Same as PostWithTask() but also returns the id of the task.
+func (c *Client) postWithTaskUpid(params map[string]interface{}, url string) (taskUpid string, exitStatus string, err error) {
+	reqbody := ParamsToBody(params)
+	resp, err := c.session.Post(url, nil, nil, &reqbody)
+	if err != nil {
+		return "", c.HandleTaskError(resp), err
+	}
+	taskResponse, err := ResponseJSON(resp)
+	if err != nil {
+		return "", "", err
+	}
+	if upid, ok := taskResponse["data"].(string); ok {
+		taskUpid = upid
+	}
+	exitStatus, err = c.WaitForCompletion(taskResponse)
+	return
+}
+
 // Makes a PUT request without waiting on proxmox for the task to complete.
 // It returns the HTTP error as 'err'.
 func (c *Client) Put(Params map[string]interface{}, url string) (err error) {
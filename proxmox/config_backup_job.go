@@ -0,0 +1,463 @@
+package proxmox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Scheduled backup job, configured in /cluster/backup.
+type ConfigBackupJob struct {
+	Comment          string                        `json:"comment,omitempty"`
+	Compression      BackupCompression             `json:"compress,omitempty"`
+	Enabled          bool                          `json:"enabled"`
+	ID               BackupJobID                   `json:"id"`
+	MailNotification BackupJobMailNotification     `json:"mail_notification,omitempty"`
+	MailTo           []string                      `json:"mail_to,omitempty"`
+	Mode             BackupMode                    `json:"mode,omitempty"`
+	Node             string                        `json:"node,omitempty"` // only run the job on this node
+	NotesTemplate    string                        `json:"notes_template,omitempty"`
+	NotificationMode BackupJobNotificationMode     `json:"notification_mode,omitempty"`
+	Retention        *ConfigStorageBackupRetention `json:"retention,omitempty"` // when nil the retention of the storage is used
+	Schedule         CalendarEvent                 `json:"schedule"`
+	Selection        BackupJobSelection            `json:"selection"`
+	Storage          string                        `json:"storage,omitempty"`
+}
+
+func (config ConfigBackupJob) mapToApiValues(create bool) (params map[string]interface{}) {
+	var deletions string
+	params = map[string]interface{}{
+		"enabled":  config.Enabled,
+		"schedule": string(config.Schedule),
+		"all":      config.Selection.All,
+	}
+	if create {
+		params["id"] = string(config.ID)
+	}
+	optional := []struct {
+		key   string
+		value string
+	}{
+		{"comment", config.Comment},
+		{"compress", string(config.Compression)},
+		{"exclude", uintArrayToCSV(config.Selection.Exclude)},
+		{"mailnotification", string(config.MailNotification)},
+		{"mailto", strings.Join(config.MailTo, ",")},
+		{"mode", string(config.Mode)},
+		{"node", config.Node},
+		{"notes-template", config.NotesTemplate},
+		{"notification-mode", string(config.NotificationMode)},
+		{"pool", string(config.Selection.Pool)},
+		{"storage", config.Storage},
+		{"vmid", uintArrayToCSV(config.Selection.Guests)},
+	}
+	for _, e := range optional {
+		if e.value != "" {
+			params[e.key] = e.value
+		} else if !create {
+			deletions = AddToList(deletions, e.key)
+		}
+	}
+	if config.Retention != nil {
+		params["prune-backups"] = config.Retention.MapStorageBackupRetention()
+	} else if !create {
+		deletions = AddToList(deletions, "prune-backups")
+	}
+	if !create && deletions != "" {
+		params["delete"] = deletions
+	}
+	return
+}
+
+func (ConfigBackupJob) mapToSDK(params map[string]interface{}) (config ConfigBackupJob) {
+	if v, isSet := params["all"]; isSet {
+		config.Selection.All = apiBool(v)
+	}
+	if v, isSet := params["comment"]; isSet {
+		config.Comment = v.(string)
+	}
+	if v, isSet := params["compress"]; isSet {
+		config.Compression = BackupCompression(v.(string))
+	}
+	config.Enabled = true
+	if v, isSet := params["enabled"]; isSet {
+		config.Enabled = apiBool(v)
+	}
+	if v, isSet := params["exclude"]; isSet {
+		config.Selection.Exclude = csvToUintArray(v.(string))
+	}
+	if v, isSet := params["id"]; isSet {
+		config.ID = BackupJobID(v.(string))
+	}
+	if v, isSet := params["mailnotification"]; isSet {
+		config.MailNotification = BackupJobMailNotification(v.(string))
+	}
+	if v, isSet := params["mailto"]; isSet && v.(string) != "" {
+		config.MailTo = strings.Split(v.(string), ",")
+	}
+	if v, isSet := params["mode"]; isSet {
+		config.Mode = BackupMode(v.(string))
+	}
+	if v, isSet := params["node"]; isSet {
+		config.Node = v.(string)
+	}
+	if v, isSet := params["notes-template"]; isSet {
+		config.NotesTemplate = v.(string)
+	}
+	if v, isSet := params["notification-mode"]; isSet {
+		config.NotificationMode = BackupJobNotificationMode(v.(string))
+	}
+	if v, isSet := params["pool"]; isSet {
+		config.Selection.Pool = PoolName(v.(string))
+	}
+	if v, isSet := params["prune-backups"]; isSet {
+		switch prune := v.(type) {
+		case string:
+			config.Retention = ConfigStorageBackupRetention{}.mapToSDK(CSVtoArray(prune))
+		case map[string]interface{}:
+			settings := make([]string, 0, len(prune))
+			for key, value := range prune {
+				settings = append(settings, key+"="+fmt.Sprint(value))
+			}
+			config.Retention = ConfigStorageBackupRetention{}.mapToSDK(settings)
+		}
+	}
+	if v, isSet := params["schedule"]; isSet {
+		config.Schedule = CalendarEvent(v.(string))
+	}
+	if v, isSet := params["storage"]; isSet {
+		config.Storage = v.(string)
+	}
+	if v, isSet := params["vmid"]; isSet {
+		config.Selection.Guests = csvToUintArray(v.(string))
+	}
+	return
+}
+
+func (config ConfigBackupJob) Validate() error {
+	if err := config.ID.Validate(); err != nil {
+		return err
+	}
+	if err := config.Schedule.Validate(); err != nil {
+		return err
+	}
+	if err := config.Selection.Validate(); err != nil {
+		return err
+	}
+	if err := config.Compression.Validate(); err != nil {
+		return err
+	}
+	if err := config.Mode.Validate(); err != nil {
+		return err
+	}
+	if err := config.MailNotification.Validate(); err != nil {
+		return err
+	}
+	if err := config.NotificationMode.Validate(); err != nil {
+		return err
+	}
+	return config.Retention.Validate()
+}
+
+// Creates the backup job when it does not exist, otherwise updates it.
+func (config ConfigBackupJob) Set(c *Client) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	exists, err := config.ID.Exists(c)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return config.Update_Unsafe(c)
+	}
+	return config.Create_Unsafe(c)
+}
+
+func (config ConfigBackupJob) Create(c *Client) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return config.Create_Unsafe(c)
+}
+
+// Create the backup job without validating the input, use ConfigBackupJob.Create() to validate the input.
+func (config ConfigBackupJob) Create_Unsafe(c *Client) error {
+	params := config.mapToApiValues(true)
+	if err := c.Post(params, "/cluster/backup"); err != nil {
+		params, _ := json.Marshal(&params)
+		return fmt.Errorf("error creating Backup Job: %v, (params: %v)", err, string(params))
+	}
+	return nil
+}
+
+func (config ConfigBackupJob) Update(c *Client) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return config.Update_Unsafe(c)
+}
+
+// Update the backup job without validating the input, use ConfigBackupJob.Update() to validate the input.
+func (config ConfigBackupJob) Update_Unsafe(c *Client) error {
+	params := config.mapToApiValues(false)
+	if err := c.Put(params, "/cluster/backup/"+string(config.ID)); err != nil {
+		params, _ := json.Marshal(&params)
+		return fmt.Errorf("error updating Backup Job: %v, (params: %v)", err, string(params))
+	}
+	return nil
+}
+
+func NewConfigBackupJobFromApi(id BackupJobID, c *Client) (*ConfigBackupJob, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+	params, err := c.GetItemConfigMapStringInterface("/cluster/backup/"+string(id), "backup job", "CONFIG")
+	if err != nil {
+		return nil, err
+	}
+	config := ConfigBackupJob{}.mapToSDK(params)
+	config.ID = id
+	return &config, nil
+}
+
+func NewConfigBackupJobFromJson(input []byte) (*ConfigBackupJob, error) {
+	config := &ConfigBackupJob{}
+	err := json.Unmarshal(input, config)
+	return config, err
+}
+
+// Returns all scheduled backup jobs.
+func ListBackupJobs(c *Client) ([]ConfigBackupJob, error) {
+	list, err := c.GetItemListInterfaceArray("/cluster/backup")
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]ConfigBackupJob, len(list))
+	for i, e := range list {
+		jobs[i] = ConfigBackupJob{}.mapToSDK(e.(map[string]interface{}))
+	}
+	return jobs, nil
+}
+
+// A guest that is not included in any backup job.
+type GuestNotBackedUp struct {
+	ID   uint      `json:"id"`
+	Name string    `json:"name"`
+	Type GuestType `json:"type"`
+}
+
+// Returns the guests that are not included in any backup job.
+// https://pve.proxmox.com/pve-docs/api-viewer/#/cluster/backup-info/not-backed-up
+func ListGuestsNotBackedUp(c *Client) ([]GuestNotBackedUp, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	list, err := c.GetItemListInterfaceArray("/cluster/backup-info/not-backed-up")
+	if err != nil {
+		return nil, err
+	}
+	guests := make([]GuestNotBackedUp, len(list))
+	for i, e := range list {
+		params := e.(map[string]interface{})
+		if v, isSet := params["vmid"]; isSet {
+			guests[i].ID = uint(v.(float64))
+		}
+		if v, isSet := params["name"]; isSet {
+			guests[i].Name = v.(string)
+		}
+		if v, isSet := params["type"]; isSet {
+			guests[i].Type = GuestType(v.(string))
+		}
+	}
+	sort.Slice(guests, func(i, j int) bool { return guests[i].ID < guests[j].ID })
+	return guests, nil
+}
+
+type BackupJobID string
+
+const (
+	BackupJobID_Error_Empty   string = "backup job id may not be empty"
+	BackupJobID_Error_Invalid string = "backup job id should start with a letter and may only contain letters, numbers, '-' and '_'"
+)
+
+var regex_BackupJobID = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9\-_]*$`)
+
+func (id BackupJobID) Delete(c *Client) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	return c.Delete("/cluster/backup/" + string(id))
+}
+
+func (id BackupJobID) Exists(c *Client) (bool, error) {
+	list, err := c.GetItemListInterfaceArray("/cluster/backup")
+	if err != nil {
+		return false, err
+	}
+	return ItemInKeyOfArray(list, "id", string(id)), nil
+}
+
+func (id BackupJobID) Validate() error {
+	if id == "" {
+		return errors.New(BackupJobID_Error_Empty)
+	}
+	if !regex_BackupJobID.MatchString(string(id)) {
+		return errors.New(BackupJobID_Error_Invalid)
+	}
+	return nil
+}
+
+// The guests included in a backup job, exactly one of All, Guests and Pool should be set.
+type BackupJobSelection struct {
+	All     bool     `json:"all,omitempty"`
+	Exclude []uint   `json:"exclude,omitempty"` // only used with All
+	Guests  []uint   `json:"guests,omitempty"`
+	Pool    PoolName `json:"pool,omitempty"`
+}
+
+const (
+	BackupJobSelection_Error_Exclude string = "exclude can only be used when all guests are selected"
+	BackupJobSelection_Error_None    string = "one of all, guests or pool should be specified"
+	BackupJobSelection_Error_Multi   string = "only one of all, guests or pool may be specified"
+)
+
+func (selection BackupJobSelection) Validate() error {
+	var count int
+	if selection.All {
+		count++
+	}
+	if len(selection.Guests) > 0 {
+		count++
+	}
+	if selection.Pool != "" {
+		if err := selection.Pool.Validate(); err != nil {
+			return err
+		}
+		count++
+	}
+	if count == 0 {
+		return errors.New(BackupJobSelection_Error_None)
+	}
+	if count > 1 {
+		return errors.New(BackupJobSelection_Error_Multi)
+	}
+	if len(selection.Exclude) > 0 && !selection.All {
+		return errors.New(BackupJobSelection_Error_Exclude)
+	}
+	return nil
+}
+
+type BackupJobMailNotification string
+
+const (
+	BackupJobMailNotification_Always  BackupJobMailNotification = "always"
+	BackupJobMailNotification_Failure BackupJobMailNotification = "failure"
+)
+
+const BackupJobMailNotification_Error_Invalid string = "mail notification should be one of [always, failure]"
+
+func (notification BackupJobMailNotification) Validate() error {
+	switch notification {
+	case "", BackupJobMailNotification_Always, BackupJobMailNotification_Failure:
+		return nil
+	}
+	return errors.New(BackupJobMailNotification_Error_Invalid)
+}
+
+type BackupJobNotificationMode string
+
+const (
+	BackupJobNotificationMode_Auto               BackupJobNotificationMode = "auto"
+	BackupJobNotificationMode_LegacySendmail     BackupJobNotificationMode = "legacy-sendmail"
+	BackupJobNotificationMode_NotificationSystem BackupJobNotificationMode = "notification-system"
+)
+
+const BackupJobNotificationMode_Error_Invalid string = "notification mode should be one of [auto, legacy-sendmail, notification-system]"
+
+func (mode BackupJobNotificationMode) Validate() error {
+	switch mode {
+	case "", BackupJobNotificationMode_Auto, BackupJobNotificationMode_LegacySendmail, BackupJobNotificationMode_NotificationSystem:
+		return nil
+	}
+	return errors.New(BackupJobNotificationMode_Error_Invalid)
+}
+
+// Schedule in the systemd like calendar event syntax of Proxmox, e.g. "daily", "mon..fri 21:00" or "*-*-01 02:30".
+// https://pve.proxmox.com/pve-docs/pve-admin-guide.html#chapter_calendar_events
+type CalendarEvent string
+
+const (
+	CalendarEvent_Error_Empty   string = "calendar event may not be empty"
+	CalendarEvent_Error_Invalid string = "invalid calendar event: "
+)
+
+var (
+	calendarEventKeywords        = []string{"minutely", "hourly", "daily", "weekly", "monthly", "yearly", "annually", "quarterly", "semiannually"}
+	regex_CalendarEventWeekday   = regexp.MustCompile(`^(mon|tue|wed|thu|fri|sat|sun)(\.\.(mon|tue|wed|thu|fri|sat|sun))?(,(mon|tue|wed|thu|fri|sat|sun)(\.\.(mon|tue|wed|thu|fri|sat|sun))?)*$`)
+	regex_CalendarEventComponent = regexp.MustCompile(`^(\*|\d{1,4}(\.\.\d{1,4})?)(/\d{1,4})?(,(\*|\d{1,4}(\.\.\d{1,4})?)(/\d{1,4})?)*$`)
+)
+
+func (event CalendarEvent) Validate() error {
+	if event == "" {
+		return errors.New(CalendarEvent_Error_Empty)
+	}
+	if inArray(calendarEventKeywords, string(event)) {
+		return nil
+	}
+	fields := strings.Fields(string(event))
+	if len(fields) > 0 && regex_CalendarEventWeekday.MatchString(fields[0]) {
+		fields = fields[1:]
+	}
+	if len(fields) > 0 && strings.Contains(fields[0], "-") {
+		if !calendarEventComponents(fields[0], "-", 2, 3) {
+			return errors.New(CalendarEvent_Error_Invalid + string(event))
+		}
+		fields = fields[1:]
+	}
+	if len(fields) > 0 {
+		if !calendarEventComponents(fields[0], ":", 1, 3) {
+			return errors.New(CalendarEvent_Error_Invalid + string(event))
+		}
+		fields = fields[1:]
+	}
+	if len(fields) > 0 {
+		return errors.New(CalendarEvent_Error_Invalid + string(event))
+	}
+	return nil
+}
+
+func calendarEventComponents(field, separator string, min, max int) bool {
+	components := strings.Split(field, separator)
+	if len(components) < min || len(components) > max {
+		return false
+	}
+	for _, e := range components {
+		if !regex_CalendarEventComponent.MatchString(e) {
+			return false
+		}
+	}
+	return true
+}
+
+func uintArrayToCSV(array []uint) string {
+	items := make([]string, len(array))
+	for i, e := range array {
+		items[i] = strconv.FormatUint(uint64(e), 10)
+	}
+	return strings.Join(items, ",")
+}
+
+func csvToUintArray(csv string) []uint {
+	array := make([]uint, 0)
+	for _, e := range strings.Split(csv, ",") {
+		if tmp, err := strconv.ParseUint(strings.TrimSpace(e), 10, 0); err == nil {
+			array = append(array, uint(tmp))
+		}
+	}
+	return array
+}
@@ -0,0 +1,308 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigBackupJob_mapToApiValues(t *testing.T) {
+	retention := &ConfigStorageBackupRetention{
+		Daily:   util.Pointer(7),
+		Hourly:  util.Pointer(0),
+		Last:    util.Pointer(3),
+		Monthly: util.Pointer(6),
+		Weekly:  util.Pointer(4),
+		Yearly:  util.Pointer(1)}
+	tests := []struct {
+		name   string
+		input  ConfigBackupJob
+		create bool
+		output map[string]interface{}
+	}{
+		{name: `Create full`,
+			create: true,
+			input: ConfigBackupJob{
+				Comment:          "nightly",
+				Compression:      BackupCompression_Zstd,
+				Enabled:          true,
+				ID:               "backup-nightly",
+				MailNotification: BackupJobMailNotification_Failure,
+				MailTo:           []string{"root@localhost", "admin@example.com"},
+				Mode:             BackupMode_Snapshot,
+				Node:             "pve",
+				NotesTemplate:    "{{guestname}}",
+				NotificationMode: BackupJobNotificationMode_Auto,
+				Retention:        retention,
+				Schedule:         "21:00",
+				Selection:        BackupJobSelection{All: true, Exclude: []uint{100, 101}},
+				Storage:          "pbs"},
+			output: map[string]interface{}{
+				"all":               true,
+				"comment":           "nightly",
+				"compress":          "zstd",
+				"enabled":           true,
+				"exclude":           "100,101",
+				"id":                "backup-nightly",
+				"mailnotification":  "failure",
+				"mailto":            "root@localhost,admin@example.com",
+				"mode":              "snapshot",
+				"node":              "pve",
+				"notes-template":    "{{guestname}}",
+				"notification-mode": "auto",
+				"prune-backups":     "keep-daily=7,keep-hourly=0,keep-last=3,keep-monthly=6,keep-weekly=4,keep-yearly=1",
+				"schedule":          "21:00",
+				"storage":           "pbs"}},
+		{name: `Create minimal`,
+			create: true,
+			input: ConfigBackupJob{
+				ID:        "backup-pool",
+				Schedule:  "daily",
+				Selection: BackupJobSelection{Pool: "prod"}},
+			output: map[string]interface{}{
+				"all":      false,
+				"enabled":  false,
+				"id":       "backup-pool",
+				"pool":     "prod",
+				"schedule": "daily"}},
+		{name: `Update minimal`,
+			input: ConfigBackupJob{
+				Enabled:   true,
+				ID:        "backup-guests",
+				Schedule:  "sat 02:00",
+				Selection: BackupJobSelection{Guests: []uint{100, 200}},
+				Storage:   "local"},
+			output: map[string]interface{}{
+				"all":      false,
+				"delete":   "comment,compress,exclude,mailnotification,mailto,mode,node,notes-template,notification-mode,pool,prune-backups",
+				"enabled":  true,
+				"schedule": "sat 02:00",
+				"storage":  "local",
+				"vmid":     "100,200"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.mapToApiValues(test.create))
+		})
+	}
+}
+
+func Test_ConfigBackupJob_mapToSDK(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output ConfigBackupJob
+	}{
+		{name: `Full`,
+			input: map[string]interface{}{
+				"all":               float64(1),
+				"comment":           "nightly",
+				"compress":          "zstd",
+				"enabled":           float64(0),
+				"exclude":           "100,101",
+				"id":                "backup-nightly",
+				"mailnotification":  "always",
+				"mailto":            "root@localhost,admin@example.com",
+				"mode":              "stop",
+				"node":              "pve",
+				"notes-template":    "{{vmid}}",
+				"notification-mode": "legacy-sendmail",
+				"prune-backups":     map[string]interface{}{"keep-last": "3", "keep-daily": float64(7)},
+				"schedule":          "mon..fri 21:00",
+				"storage":           "pbs"},
+			output: ConfigBackupJob{
+				Comment:          "nightly",
+				Compression:      BackupCompression_Zstd,
+				ID:               "backup-nightly",
+				MailNotification: BackupJobMailNotification_Always,
+				MailTo:           []string{"root@localhost", "admin@example.com"},
+				Mode:             BackupMode_Stop,
+				Node:             "pve",
+				NotesTemplate:    "{{vmid}}",
+				NotificationMode: BackupJobNotificationMode_LegacySendmail,
+				Retention: &ConfigStorageBackupRetention{
+					Daily:   util.Pointer(7),
+					Hourly:  util.Pointer(0),
+					Last:    util.Pointer(3),
+					Monthly: util.Pointer(0),
+					Weekly:  util.Pointer(0),
+					Yearly:  util.Pointer(0)},
+				Schedule:  "mon..fri 21:00",
+				Selection: BackupJobSelection{All: true, Exclude: []uint{100, 101}},
+				Storage:   "pbs"}},
+		{name: `Minimal`,
+			input: map[string]interface{}{
+				"id":            "backup-guests",
+				"prune-backups": "keep-all=1",
+				"schedule":      "daily",
+				"vmid":          "100,200"},
+			output: ConfigBackupJob{
+				Enabled:   true,
+				ID:        "backup-guests",
+				Schedule:  "daily",
+				Selection: BackupJobSelection{Guests: []uint{100, 200}}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, ConfigBackupJob{}.mapToSDK(test.input))
+		})
+	}
+}
+
+// A job written by Proxmox should pass validation when it is updated.
+func Test_ConfigBackupJob_mapToSDK_Validate(t *testing.T) {
+	for _, compression := range []string{"0", "1", "gzip", "lzo", "zstd"} {
+		t.Run(compression, func(t *testing.T) {
+			config := ConfigBackupJob{}.mapToSDK(map[string]interface{}{
+				"all":      float64(1),
+				"compress": compression,
+				"id":       "backup-nightly",
+				"schedule": "21:00"})
+			require.Equal(t, BackupCompression(compression), config.Compression)
+			require.NoError(t, config.Validate())
+		})
+	}
+}
+
+func Test_ConfigBackupJob_Validate(t *testing.T) {
+	valid := func() ConfigBackupJob {
+		return ConfigBackupJob{ID: "backup-1", Schedule: "daily", Selection: BackupJobSelection{All: true}}
+	}
+	tests := []struct {
+		name   string
+		input  func() ConfigBackupJob
+		output error
+	}{
+		{name: `Valid`,
+			input: valid},
+		{name: `Invalid ID`,
+			input:  func() ConfigBackupJob { c := valid(); c.ID = "1backup"; return c },
+			output: errors.New(BackupJobID_Error_Invalid)},
+		{name: `Invalid Schedule`,
+			input:  func() ConfigBackupJob { c := valid(); c.Schedule = ""; return c },
+			output: errors.New(CalendarEvent_Error_Empty)},
+		{name: `Invalid Selection`,
+			input:  func() ConfigBackupJob { c := valid(); c.Selection.Pool = "prod"; return c },
+			output: errors.New(BackupJobSelection_Error_Multi)},
+		{name: `Invalid Compression`,
+			input:  func() ConfigBackupJob { c := valid(); c.Compression = "xz"; return c },
+			output: errors.New(BackupCompression_Error_Invalid)},
+		{name: `Invalid Mode`,
+			input:  func() ConfigBackupJob { c := valid(); c.Mode = "pause"; return c },
+			output: errors.New(BackupMode_Error_Invalid)},
+		{name: `Invalid MailNotification`,
+			input:  func() ConfigBackupJob { c := valid(); c.MailNotification = "never"; return c },
+			output: errors.New(BackupJobMailNotification_Error_Invalid)},
+		{name: `Invalid NotificationMode`,
+			input:  func() ConfigBackupJob { c := valid(); c.NotificationMode = "sendmail"; return c },
+			output: errors.New(BackupJobNotificationMode_Error_Invalid)},
+		{name: `Invalid Retention`,
+			input: func() ConfigBackupJob {
+				c := valid()
+				c.Retention = &ConfigStorageBackupRetention{Last: util.Pointer(1)}
+				return c
+			},
+			output: ErrorKeyNotSet("backupretention:{ hourly }")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input().Validate())
+		})
+	}
+}
+
+func Test_BackupJobID_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  BackupJobID
+		output error
+	}{
+		{name: `Valid`, input: "backup-6a0f3b2c-1d4e"},
+		{name: `Valid underscore`, input: "nightly_backup"},
+		{name: `Invalid empty`, output: errors.New(BackupJobID_Error_Empty)},
+		{name: `Invalid start`, input: "-backup", output: errors.New(BackupJobID_Error_Invalid)},
+		{name: `Invalid character`, input: "backup job", output: errors.New(BackupJobID_Error_Invalid)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
+
+func Test_BackupJobSelection_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  BackupJobSelection
+		output error
+	}{
+		{name: `Valid All`, input: BackupJobSelection{All: true}},
+		{name: `Valid All Exclude`, input: BackupJobSelection{All: true, Exclude: []uint{100}}},
+		{name: `Valid Guests`, input: BackupJobSelection{Guests: []uint{100, 101}}},
+		{name: `Valid Pool`, input: BackupJobSelection{Pool: "prod"}},
+		{name: `Invalid none`, output: errors.New(BackupJobSelection_Error_None)},
+		{name: `Invalid multi`,
+			input:  BackupJobSelection{Guests: []uint{100}, Pool: "prod"},
+			output: errors.New(BackupJobSelection_Error_Multi)},
+		{name: `Invalid Exclude`,
+			input:  BackupJobSelection{Pool: "prod", Exclude: []uint{100}},
+			output: errors.New(BackupJobSelection_Error_Exclude)},
+		{name: `Invalid Pool`,
+			input:  BackupJobSelection{Pool: "prod!"},
+			output: errors.New(PoolName_Error_Characters)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
+
+func Test_CalendarEvent_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []CalendarEvent
+		output error
+	}{
+		{name: `Valid`,
+			input: []CalendarEvent{
+				"daily",
+				"hourly",
+				"21:00",
+				"02:30:15",
+				"*/5",
+				"sat",
+				"sat 02:00",
+				"mon..fri 21:00",
+				"mon,wed,fri 0/2:00",
+				"*-*-01 02:30",
+				"12-24",
+				"2024-06-01 00:00",
+				"sun 12..18/2:30",
+				"*:0,15,30,45"}},
+		{name: `Invalid`,
+			input: []CalendarEvent{
+				"every day",
+				"mon..fri 21:00 extra",
+				"monday 21:00",
+				"21:00:00:00",
+				"*-*-*-*",
+				"a:b"},
+			output: errors.New(CalendarEvent_Error_Invalid)},
+	}
+	for _, test := range tests {
+		for _, input := range test.input {
+			t.Run(test.name+": "+string(input), func(t *testing.T) {
+				err := input.Validate()
+				if test.output == nil {
+					require.NoError(t, err)
+				} else {
+					require.Equal(t, errors.New(CalendarEvent_Error_Invalid+string(input)), err)
+				}
+			})
+		}
+	}
+	require.Equal(t, errors.New(CalendarEvent_Error_Empty), CalendarEvent("").Validate())
+}
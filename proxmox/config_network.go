@@ -2,39 +2,45 @@ package proxmox
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/Telmate/proxmox-api-go/internal/parse"
 )
 
 // ConfigNetwork maps go variables to API parameters.
 type ConfigNetwork struct {
-	Iface              string `json:"iface,omitempty"`
-	Node               string `json:"node,omitempty"`
-	Type               string `json:"type,omitempty"`
-	Address            string `json:"address,omitempty"`
-	Address6           string `json:"address6,omitempty"`
-	Autostart          bool   `json:"autostart,omitempty"`
-	BondPrimary        string `json:"bond-primary,omitempty"`
-	BondMode           string `json:"bond_mode,omitempty"`
-	BondXmitHashPolicy string `json:"bond_xmit_hash_policy,omitempty"`
-	BridgePorts        string `json:"bridge_ports,omitempty"`
-	BridgeVlanAware    bool   `json:"bridge_vlan_aware,omitempty"`
-	CIDR               string `json:"cidr,omitempty"`
-	CIDR6              string `json:"cidr6,omitempty"`
-	Comments           string `json:"comments,omitempty"`
-	Comments6          string `json:"comments6,omitempty"`
-	Gateway            string `json:"gateway,omitempty"`
-	Gateway6           string `json:"gateway6,omitempty"`
-	MTU                int    `json:"mtu,omitempty"`
-	Netmask            string `json:"netmask,omitempty"`
-	Netmask6           int    `json:"netmask6,omitempty"`
-	OVSBonds           string `json:"ovs_bonds,omitempty"`
-	OVSBridge          string `json:"ovs_bridge,omitempty"`
-	OVSOptions         string `json:"ovs_options,omitempty"`
-	OVSPorts           string `json:"ovs_ports,omitempty"`
-	OVSTag             int    `json:"ovs_tag,omitempty"`
-	Slaves             string `json:"slaves,omitempty"`
-	VlanID             int    `json:"vlan-id,omitempty"`
-	VlanRawDevice      string `json:"vlan-raw-device,omitempty"`
+	Iface              string                    `json:"iface,omitempty"`
+	Node               string                    `json:"node,omitempty"`
+	Type               NetworkInterfaceType      `json:"type,omitempty"`
+	Address            string                    `json:"address,omitempty"`
+	Address6           string                    `json:"address6,omitempty"`
+	Autostart          bool                      `json:"autostart,omitempty"`
+	BondPrimary        string                    `json:"bond-primary,omitempty"`
+	BondMode           NetworkBondMode           `json:"bond_mode,omitempty"`
+	BondXmitHashPolicy NetworkBondXmitHashPolicy `json:"bond_xmit_hash_policy,omitempty"`
+	BridgePorts        string                    `json:"bridge_ports,omitempty"`
+	BridgeVlanAware    bool                      `json:"bridge_vlan_aware,omitempty"`
+	CIDR               string                    `json:"cidr,omitempty"`
+	CIDR6              string                    `json:"cidr6,omitempty"`
+	Comments           string                    `json:"comments,omitempty"`
+	Comments6          string                    `json:"comments6,omitempty"`
+	Gateway            string                    `json:"gateway,omitempty"`
+	Gateway6           string                    `json:"gateway6,omitempty"`
+	MTU                int                       `json:"mtu,omitempty"`
+	Netmask            string                    `json:"netmask,omitempty"`
+	Netmask6           int                       `json:"netmask6,omitempty"`
+	OVSBonds           string                    `json:"ovs_bonds,omitempty"`
+	OVSBridge          string                    `json:"ovs_bridge,omitempty"`
+	OVSOptions         string                    `json:"ovs_options,omitempty"`
+	OVSPorts           string                    `json:"ovs_ports,omitempty"`
+	OVSTag             int                       `json:"ovs_tag,omitempty"`
+	Slaves             string                    `json:"slaves,omitempty"`
+	VlanID             int                       `json:"vlan-id,omitempty"`
+	VlanRawDevice      string                    `json:"vlan-raw-device,omitempty"`
 }
 
 // NewConfigNetworkFromJSON takes in a byte array from a json encoded network
@@ -83,3 +89,308 @@ func (config ConfigNetwork) UpdateNetwork(client *Client) (err error) {
 	}
 	return
 }
+
+func (ConfigNetwork) mapToSDK(params map[string]interface{}) (config ConfigNetwork) {
+	if v, isSet := params["iface"]; isSet {
+		config.Iface = v.(string)
+	}
+	if v, isSet := params["type"]; isSet {
+		config.Type = NetworkInterfaceType(v.(string))
+	}
+	if v, isSet := params["address"]; isSet {
+		config.Address = v.(string)
+	}
+	if v, isSet := params["address6"]; isSet {
+		config.Address6 = v.(string)
+	}
+	if v, isSet := params["autostart"]; isSet {
+		config.Autostart = apiBool(v)
+	}
+	if v, isSet := params["bond-primary"]; isSet {
+		config.BondPrimary = v.(string)
+	}
+	if v, isSet := params["bond_mode"]; isSet {
+		config.BondMode = NetworkBondMode(v.(string))
+	}
+	if v, isSet := params["bond_xmit_hash_policy"]; isSet {
+		config.BondXmitHashPolicy = NetworkBondXmitHashPolicy(v.(string))
+	}
+	if v, isSet := params["bridge_ports"]; isSet {
+		config.BridgePorts = v.(string)
+	}
+	if v, isSet := params["bridge_vlan_aware"]; isSet {
+		config.BridgeVlanAware = apiBool(v)
+	}
+	if v, isSet := params["cidr"]; isSet {
+		config.CIDR = v.(string)
+	}
+	if v, isSet := params["cidr6"]; isSet {
+		config.CIDR6 = v.(string)
+	}
+	if v, isSet := params["comments"]; isSet {
+		config.Comments = strings.TrimSuffix(v.(string), "\n")
+	}
+	if v, isSet := params["comments6"]; isSet {
+		config.Comments6 = strings.TrimSuffix(v.(string), "\n")
+	}
+	if v, isSet := params["gateway"]; isSet {
+		config.Gateway = v.(string)
+	}
+	if v, isSet := params["gateway6"]; isSet {
+		config.Gateway6 = v.(string)
+	}
+	if v, isSet := params["mtu"]; isSet {
+		config.MTU, _ = parse.Int(v)
+	}
+	if v, isSet := params["netmask"]; isSet {
+		config.Netmask = v.(string)
+	}
+	if v, isSet := params["netmask6"]; isSet {
+		config.Netmask6, _ = parse.Int(v)
+	}
+	if v, isSet := params["ovs_bonds"]; isSet {
+		config.OVSBonds = v.(string)
+	}
+	if v, isSet := params["ovs_bridge"]; isSet {
+		config.OVSBridge = v.(string)
+	}
+	if v, isSet := params["ovs_options"]; isSet {
+		config.OVSOptions = v.(string)
+	}
+	if v, isSet := params["ovs_ports"]; isSet {
+		config.OVSPorts = v.(string)
+	}
+	if v, isSet := params["ovs_tag"]; isSet {
+		config.OVSTag, _ = parse.Int(v)
+	}
+	if v, isSet := params["slaves"]; isSet {
+		config.Slaves = v.(string)
+	}
+	if v, isSet := params["vlan-id"]; isSet {
+		config.VlanID, _ = parse.Int(v)
+	}
+	if v, isSet := params["vlan-raw-device"]; isSet {
+		config.VlanRawDevice = v.(string)
+	}
+	return
+}
+
+const (
+	ConfigNetwork_Error_BondPrimary        string = "bond-primary is only supported by bond mode " + string(NetworkBondMode_ActiveBackup)
+	ConfigNetwork_Error_BondSlaves         string = "a bond should have at least one slave"
+	ConfigNetwork_Error_BondXmitHashPolicy string = "bond_xmit_hash_policy is only supported by bond modes " + string(NetworkBondMode_BalanceXor) + " and " + string(NetworkBondMode_8023ad)
+	ConfigNetwork_Error_CIDR               string = "cidr should be an IPv4 address in CIDR notation"
+	ConfigNetwork_Error_CIDR6              string = "cidr6 should be an IPv6 address in CIDR notation"
+	ConfigNetwork_Error_Gateway            string = "gateway should be an IPv4 address"
+	ConfigNetwork_Error_Gateway6           string = "gateway6 should be an IPv6 address"
+	ConfigNetwork_Error_Iface              string = "iface should start with a letter and only contain letters, numbers and underscores, optionally followed by a '.' or ':' and a number"
+	ConfigNetwork_Error_MTU                string = "mtu should be in the range 1280-65520"
+	ConfigNetwork_Error_NodeEmpty          string = "node may not be empty"
+	ConfigNetwork_Error_OVSBondMode        string = "OVS bonds only support the bond modes " + string(NetworkBondMode_ActiveBackup) + ", " + string(NetworkBondMode_BalanceSlb) + ", " + string(NetworkBondMode_LacpBalanceSlb) + " and " + string(NetworkBondMode_LacpBalanceTcp)
+	ConfigNetwork_Error_OVSTag             string = "ovs_tag should be in the range 1-4094"
+	ConfigNetwork_Error_Port               string = "invalid port: "
+	ConfigNetwork_Error_VlanID             string = "vlan-id should be in the range 1-4094"
+	ConfigNetwork_Error_VlanRawDevice      string = "vlan-raw-device should be set when the vlan is not named <device>.<vlan id>"
+)
+
+var regex_NetworkIface = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{1,20}([:.]\d+)?$`)
+
+func (config ConfigNetwork) Validate() error {
+	if config.Node == "" {
+		return errors.New(ConfigNetwork_Error_NodeEmpty)
+	}
+	if err := validateNetworkIface(config.Iface); err != nil {
+		return err
+	}
+	if err := config.Type.Validate(); err != nil {
+		return err
+	}
+	switch config.Type {
+	case NetworkInterfaceType_Bridge:
+		if err := validateNetworkPorts(config.BridgePorts); err != nil {
+			return err
+		}
+	case NetworkInterfaceType_Bond:
+		if strings.TrimSpace(config.Slaves) == "" {
+			return errors.New(ConfigNetwork_Error_BondSlaves)
+		}
+		if err := validateNetworkPorts(config.Slaves); err != nil {
+			return err
+		}
+		if err := config.BondMode.validateLinux(); err != nil {
+			return err
+		}
+	case NetworkInterfaceType_Vlan:
+		if config.VlanID != 0 && (config.VlanID < 1 || config.VlanID > 4094) {
+			return errors.New(ConfigNetwork_Error_VlanID)
+		}
+		if !strings.Contains(config.Iface, ".") && config.VlanRawDevice == "" {
+			return errors.New(ConfigNetwork_Error_VlanRawDevice)
+		}
+	case NetworkInterfaceType_OVSBridge:
+		if err := validateNetworkPorts(config.OVSPorts); err != nil {
+			return err
+		}
+	case NetworkInterfaceType_OVSBond:
+		if strings.TrimSpace(config.OVSBonds) == "" {
+			return errors.New(ConfigNetwork_Error_BondSlaves)
+		}
+		if err := validateNetworkPorts(config.OVSBonds); err != nil {
+			return err
+		}
+		if err := config.BondMode.validateOVS(); err != nil {
+			return err
+		}
+	}
+	if config.BondPrimary != "" && config.BondMode != NetworkBondMode_ActiveBackup {
+		return errors.New(ConfigNetwork_Error_BondPrimary)
+	}
+	if config.BondXmitHashPolicy != "" {
+		if config.BondMode != NetworkBondMode_BalanceXor && config.BondMode != NetworkBondMode_8023ad {
+			return errors.New(ConfigNetwork_Error_BondXmitHashPolicy)
+		}
+		if err := config.BondXmitHashPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+	if config.CIDR != "" {
+		if ip, _, err := net.ParseCIDR(config.CIDR); err != nil || ip.To4() == nil {
+			return errors.New(ConfigNetwork_Error_CIDR)
+		}
+	}
+	if config.CIDR6 != "" {
+		if ip, _, err := net.ParseCIDR(config.CIDR6); err != nil || ip.To4() != nil {
+			return errors.New(ConfigNetwork_Error_CIDR6)
+		}
+	}
+	if config.Gateway != "" {
+		if ip := net.ParseIP(config.Gateway); ip == nil || ip.To4() == nil {
+			return errors.New(ConfigNetwork_Error_Gateway)
+		}
+	}
+	if config.Gateway6 != "" {
+		if ip := net.ParseIP(config.Gateway6); ip == nil || ip.To4() != nil {
+			return errors.New(ConfigNetwork_Error_Gateway6)
+		}
+	}
+	if config.MTU != 0 && (config.MTU < 1280 || config.MTU > 65520) {
+		return errors.New(ConfigNetwork_Error_MTU)
+	}
+	if config.OVSTag != 0 && (config.OVSTag < 1 || config.OVSTag > 4094) {
+		return errors.New(ConfigNetwork_Error_OVSTag)
+	}
+	return nil
+}
+
+// CreateWithValidate validates the config before creating the network.
+func (config ConfigNetwork) CreateWithValidate(client *Client) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return config.CreateNetwork(client)
+}
+
+// UpdateWithValidate validates the config before updating the network.
+func (config ConfigNetwork) UpdateWithValidate(client *Client) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return config.UpdateNetwork(client)
+}
+
+func validateNetworkIface(iface string) error {
+	if !regex_NetworkIface.MatchString(iface) {
+		return errors.New(ConfigNetwork_Error_Iface)
+	}
+	return nil
+}
+
+// Validates a space or comma separated list of interfaces, an empty list is valid.
+func validateNetworkPorts(ports string) error {
+	for _, port := range strings.FieldsFunc(ports, func(r rune) bool { return r == ' ' || r == ',' }) {
+		if !regex_NetworkIface.MatchString(port) {
+			return errors.New(ConfigNetwork_Error_Port + port)
+		}
+	}
+	return nil
+}
+
+type NetworkInterfaceType string
+
+const (
+	NetworkInterfaceType_Alias      NetworkInterfaceType = "alias"
+	NetworkInterfaceType_Bond       NetworkInterfaceType = "bond"
+	NetworkInterfaceType_Bridge     NetworkInterfaceType = "bridge"
+	NetworkInterfaceType_Eth        NetworkInterfaceType = "eth"
+	NetworkInterfaceType_OVSBond    NetworkInterfaceType = "OVSBond"
+	NetworkInterfaceType_OVSBridge  NetworkInterfaceType = "OVSBridge"
+	NetworkInterfaceType_OVSIntPort NetworkInterfaceType = "OVSIntPort"
+	NetworkInterfaceType_OVSPort    NetworkInterfaceType = "OVSPort"
+	NetworkInterfaceType_Vlan       NetworkInterfaceType = "vlan"
+)
+
+const NetworkInterfaceType_Error_Invalid string = "type should be one of: alias, bond, bridge, eth, OVSBond, OVSBridge, OVSIntPort, OVSPort, vlan"
+
+func (t NetworkInterfaceType) Validate() error {
+	switch t {
+	case NetworkInterfaceType_Alias, NetworkInterfaceType_Bond, NetworkInterfaceType_Bridge, NetworkInterfaceType_Eth,
+		NetworkInterfaceType_OVSBond, NetworkInterfaceType_OVSBridge, NetworkInterfaceType_OVSIntPort, NetworkInterfaceType_OVSPort,
+		NetworkInterfaceType_Vlan:
+		return nil
+	}
+	return errors.New(NetworkInterfaceType_Error_Invalid)
+}
+
+type NetworkBondMode string
+
+const (
+	NetworkBondMode_8023ad         NetworkBondMode = "802.3ad"
+	NetworkBondMode_ActiveBackup   NetworkBondMode = "active-backup"
+	NetworkBondMode_BalanceAlb     NetworkBondMode = "balance-alb"
+	NetworkBondMode_BalanceRr      NetworkBondMode = "balance-rr"
+	NetworkBondMode_BalanceSlb     NetworkBondMode = "balance-slb" // OVS only
+	NetworkBondMode_BalanceTlb     NetworkBondMode = "balance-tlb"
+	NetworkBondMode_BalanceXor     NetworkBondMode = "balance-xor"
+	NetworkBondMode_Broadcast      NetworkBondMode = "broadcast"
+	NetworkBondMode_LacpBalanceSlb NetworkBondMode = "lacp-balance-slb" // OVS only
+	NetworkBondMode_LacpBalanceTcp NetworkBondMode = "lacp-balance-tcp" // OVS only
+)
+
+const NetworkBondMode_Error_Invalid string = "bond mode should be one of: 802.3ad, active-backup, balance-alb, balance-rr, balance-tlb, balance-xor, broadcast"
+
+// Validates the mode of a Linux bond, an empty mode defaults to balance-rr.
+func (mode NetworkBondMode) validateLinux() error {
+	switch mode {
+	case "", NetworkBondMode_8023ad, NetworkBondMode_ActiveBackup, NetworkBondMode_BalanceAlb, NetworkBondMode_BalanceRr,
+		NetworkBondMode_BalanceTlb, NetworkBondMode_BalanceXor, NetworkBondMode_Broadcast:
+		return nil
+	}
+	return errors.New(NetworkBondMode_Error_Invalid)
+}
+
+// Validates the mode of an OVS bond, an empty mode defaults to active-backup.
+func (mode NetworkBondMode) validateOVS() error {
+	switch mode {
+	case "", NetworkBondMode_ActiveBackup, NetworkBondMode_BalanceSlb, NetworkBondMode_LacpBalanceSlb, NetworkBondMode_LacpBalanceTcp:
+		return nil
+	}
+	return errors.New(ConfigNetwork_Error_OVSBondMode)
+}
+
+type NetworkBondXmitHashPolicy string
+
+const (
+	NetworkBondXmitHashPolicy_Layer2   NetworkBondXmitHashPolicy = "layer2"
+	NetworkBondXmitHashPolicy_Layer2_3 NetworkBondXmitHashPolicy = "layer2+3"
+	NetworkBondXmitHashPolicy_Layer3_4 NetworkBondXmitHashPolicy = "layer3+4"
+)
+
+const NetworkBondXmitHashPolicy_Error_Invalid string = "bond_xmit_hash_policy should be one of: layer2, layer2+3, layer3+4"
+
+func (policy NetworkBondXmitHashPolicy) Validate() error {
+	switch policy {
+	case NetworkBondXmitHashPolicy_Layer2, NetworkBondXmitHashPolicy_Layer2_3, NetworkBondXmitHashPolicy_Layer3_4:
+		return nil
+	}
+	return errors.New(NetworkBondXmitHashPolicy_Error_Invalid)
+}
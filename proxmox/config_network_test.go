@@ -0,0 +1,150 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigNetwork_mapToSDK(t *testing.T) {
+	require.Equal(t,
+		ConfigNetwork{
+			Autostart:       true,
+			BridgePorts:     "eno1",
+			BridgeVlanAware: true,
+			CIDR:            "192.168.1.10/24",
+			Comments:        "main bridge",
+			Gateway:         "192.168.1.1",
+			Iface:           "vmbr0",
+			MTU:             9000,
+			Type:            NetworkInterfaceType_Bridge},
+		ConfigNetwork{}.mapToSDK(map[string]interface{}{
+			"active":            float64(1),
+			"autostart":         float64(1),
+			"bridge_ports":      "eno1",
+			"bridge_vlan_aware": float64(1),
+			"cidr":              "192.168.1.10/24",
+			"comments":          "main bridge\n",
+			"families":          []interface{}{"inet"},
+			"gateway":           "192.168.1.1",
+			"iface":             "vmbr0",
+			"mtu":               "9000",
+			"type":              "bridge"}))
+	require.Equal(t,
+		ConfigNetwork{
+			BondMode:           NetworkBondMode_8023ad,
+			BondXmitHashPolicy: NetworkBondXmitHashPolicy_Layer3_4,
+			Iface:              "bond0",
+			Slaves:             "eno1 eno2",
+			Type:               NetworkInterfaceType_Bond},
+		ConfigNetwork{}.mapToSDK(map[string]interface{}{
+			"bond_mode":             "802.3ad",
+			"bond_xmit_hash_policy": "layer3+4",
+			"iface":                 "bond0",
+			"slaves":                "eno1 eno2",
+			"type":                  "bond"}))
+	require.Equal(t,
+		ConfigNetwork{Iface: "vlan20", Type: NetworkInterfaceType_Vlan, VlanID: 20, VlanRawDevice: "bond0"},
+		ConfigNetwork{}.mapToSDK(map[string]interface{}{
+			"iface":           "vlan20",
+			"type":            "vlan",
+			"vlan-id":         "20",
+			"vlan-raw-device": "bond0"}))
+}
+
+func Test_ConfigNetwork_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  ConfigNetwork
+		output error
+	}{
+		{name: `Valid Bridge`,
+			input: ConfigNetwork{Node: "pve", Iface: "vmbr0", Type: NetworkInterfaceType_Bridge, BridgePorts: "eno1 bond0.20", CIDR: "192.168.1.10/24", Gateway: "192.168.1.1", CIDR6: "fd00::10/64", Gateway6: "fd00::1", MTU: 9000}},
+		{name: `Valid Bridge no ports`,
+			input: ConfigNetwork{Node: "pve", Iface: "vmbr1", Type: NetworkInterfaceType_Bridge}},
+		{name: `Valid Bond`,
+			input: ConfigNetwork{Node: "pve", Iface: "bond0", Type: NetworkInterfaceType_Bond, Slaves: "eno1,eno2", BondMode: NetworkBondMode_8023ad, BondXmitHashPolicy: NetworkBondXmitHashPolicy_Layer2_3}},
+		{name: `Valid Bond active-backup`,
+			input: ConfigNetwork{Node: "pve", Iface: "bond0", Type: NetworkInterfaceType_Bond, Slaves: "eno1 eno2", BondMode: NetworkBondMode_ActiveBackup, BondPrimary: "eno1"}},
+		{name: `Valid Vlan dot notation`,
+			input: ConfigNetwork{Node: "pve", Iface: "eno1.20", Type: NetworkInterfaceType_Vlan}},
+		{name: `Valid Vlan raw device`,
+			input: ConfigNetwork{Node: "pve", Iface: "vlan20", Type: NetworkInterfaceType_Vlan, VlanID: 20, VlanRawDevice: "eno1"}},
+		{name: `Valid OVSBond`,
+			input: ConfigNetwork{Node: "pve", Iface: "bond1", Type: NetworkInterfaceType_OVSBond, OVSBonds: "eno3 eno4", BondMode: NetworkBondMode_LacpBalanceTcp, OVSBridge: "vmbr2"}},
+		{name: `Valid OVSIntPort`,
+			input: ConfigNetwork{Node: "pve", Iface: "mgmt", Type: NetworkInterfaceType_OVSIntPort, OVSBridge: "vmbr2", OVSTag: 10}},
+		{name: `Invalid Node`,
+			input:  ConfigNetwork{Iface: "vmbr0", Type: NetworkInterfaceType_Bridge},
+			output: errors.New(ConfigNetwork_Error_NodeEmpty)},
+		{name: `Invalid Iface`,
+			input:  ConfigNetwork{Node: "pve", Iface: "0vmbr", Type: NetworkInterfaceType_Bridge},
+			output: errors.New(ConfigNetwork_Error_Iface)},
+		{name: `Invalid Type`,
+			input:  ConfigNetwork{Node: "pve", Iface: "vmbr0", Type: "wifi"},
+			output: errors.New(NetworkInterfaceType_Error_Invalid)},
+		{name: `Invalid Bridge port`,
+			input:  ConfigNetwork{Node: "pve", Iface: "vmbr0", Type: NetworkInterfaceType_Bridge, BridgePorts: "eno1 e$"},
+			output: errors.New(ConfigNetwork_Error_Port + "e$")},
+		{name: `Invalid Bond no slaves`,
+			input:  ConfigNetwork{Node: "pve", Iface: "bond0", Type: NetworkInterfaceType_Bond},
+			output: errors.New(ConfigNetwork_Error_BondSlaves)},
+		{name: `Invalid Bond mode`,
+			input:  ConfigNetwork{Node: "pve", Iface: "bond0", Type: NetworkInterfaceType_Bond, Slaves: "eno1", BondMode: NetworkBondMode_BalanceSlb},
+			output: errors.New(NetworkBondMode_Error_Invalid)},
+		{name: `Invalid Bond primary`,
+			input:  ConfigNetwork{Node: "pve", Iface: "bond0", Type: NetworkInterfaceType_Bond, Slaves: "eno1", BondMode: NetworkBondMode_BalanceRr, BondPrimary: "eno1"},
+			output: errors.New(ConfigNetwork_Error_BondPrimary)},
+		{name: `Invalid Bond xmit hash policy mode`,
+			input:  ConfigNetwork{Node: "pve", Iface: "bond0", Type: NetworkInterfaceType_Bond, Slaves: "eno1", BondMode: NetworkBondMode_ActiveBackup, BondXmitHashPolicy: NetworkBondXmitHashPolicy_Layer2},
+			output: errors.New(ConfigNetwork_Error_BondXmitHashPolicy)},
+		{name: `Invalid Bond xmit hash policy`,
+			input:  ConfigNetwork{Node: "pve", Iface: "bond0", Type: NetworkInterfaceType_Bond, Slaves: "eno1", BondMode: NetworkBondMode_BalanceXor, BondXmitHashPolicy: "layer4"},
+			output: errors.New(NetworkBondXmitHashPolicy_Error_Invalid)},
+		{name: `Invalid OVSBond mode`,
+			input:  ConfigNetwork{Node: "pve", Iface: "bond1", Type: NetworkInterfaceType_OVSBond, OVSBonds: "eno3", BondMode: NetworkBondMode_8023ad},
+			output: errors.New(ConfigNetwork_Error_OVSBondMode)},
+		{name: `Invalid Vlan ID`,
+			input:  ConfigNetwork{Node: "pve", Iface: "eno1.5000", Type: NetworkInterfaceType_Vlan, VlanID: 5000},
+			output: errors.New(ConfigNetwork_Error_VlanID)},
+		{name: `Invalid Vlan raw device`,
+			input:  ConfigNetwork{Node: "pve", Iface: "vlan20", Type: NetworkInterfaceType_Vlan, VlanID: 20},
+			output: errors.New(ConfigNetwork_Error_VlanRawDevice)},
+		{name: `Invalid CIDR`,
+			input:  ConfigNetwork{Node: "pve", Iface: "vmbr0", Type: NetworkInterfaceType_Bridge, CIDR: "192.168.1.10"},
+			output: errors.New(ConfigNetwork_Error_CIDR)},
+		{name: `Invalid CIDR IPv6`,
+			input:  ConfigNetwork{Node: "pve", Iface: "vmbr0", Type: NetworkInterfaceType_Bridge, CIDR: "fd00::10/64"},
+			output: errors.New(ConfigNetwork_Error_CIDR)},
+		{name: `Invalid CIDR6`,
+			input:  ConfigNetwork{Node: "pve", Iface: "vmbr0", Type: NetworkInterfaceType_Bridge, CIDR6: "192.168.1.10/24"},
+			output: errors.New(ConfigNetwork_Error_CIDR6)},
+		{name: `Invalid Gateway`,
+			input:  ConfigNetwork{Node: "pve", Iface: "vmbr0", Type: NetworkInterfaceType_Bridge, Gateway: "fd00::1"},
+			output: errors.New(ConfigNetwork_Error_Gateway)},
+		{name: `Invalid Gateway6`,
+			input:  ConfigNetwork{Node: "pve", Iface: "vmbr0", Type: NetworkInterfaceType_Bridge, Gateway6: "192.168.1.1"},
+			output: errors.New(ConfigNetwork_Error_Gateway6)},
+		{name: `Invalid MTU`,
+			input:  ConfigNetwork{Node: "pve", Iface: "vmbr0", Type: NetworkInterfaceType_Bridge, MTU: 1000},
+			output: errors.New(ConfigNetwork_Error_MTU)},
+		{name: `Invalid OVSTag`,
+			input:  ConfigNetwork{Node: "pve", Iface: "mgmt", Type: NetworkInterfaceType_OVSIntPort, OVSTag: 4095},
+			output: errors.New(ConfigNetwork_Error_OVSTag)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
+
+func Test_NetworkInterfaceType_Validate(t *testing.T) {
+	for _, e := range []NetworkInterfaceType{"alias", "bond", "bridge", "eth", "OVSBond", "OVSBridge", "OVSIntPort", "OVSPort", "vlan"} {
+		require.NoError(t, e.Validate(), e)
+	}
+	for _, e := range []NetworkInterfaceType{"", "ovsbridge", "any_bridge"} {
+		require.Equal(t, errors.New(NetworkInterfaceType_Error_Invalid), e.Validate(), e)
+	}
+}
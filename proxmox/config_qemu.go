@@ -603,6 +603,9 @@ func (newConfig ConfigQemu) setAdvanced(currentConfig *ConfigQemu, rebootIfNeede
 		var markedDisks qemuUpdateChanges
 		if newConfig.Disks != nil && currentConfig.Disks != nil {
 			markedDisks = *newConfig.Disks.markDiskChanges(*currentConfig.Disks)
+			if len(markedDisks.Shrink) > 0 { // disks can only be grown
+				return false, &QemuDiskShrinkError{Disks: markedDisks.Shrink}
+			}
 			for _, e := range markedDisks.Move { // move disk to different storage or change disk format
 				_, err = e.move(true, vmr, client)
 				if err != nil {
@@ -922,15 +925,30 @@ func NewConfigQemuFromApi(vmr *VmRef, client *Client) (config *ConfigQemu, err e
 
 // Useful waiting for ISO install to complete
 func WaitForShutdown(vmr *VmRef, client *Client) (err error) {
-	for ii := 0; ii < 100; ii++ {
+	return WaitForShutdownWithDeadline(vmr, client, time.Now().Add(500*time.Second), 5*time.Second)
+}
+
+// Waits until the guest is stopped, checking every interval until the deadline has passed.
+// When interval is 0 the guest is checked every TaskStatusCheckInterval seconds.
+func WaitForShutdownWithDeadline(vmr *VmRef, client *Client, deadline time.Time, interval time.Duration) error {
+	if client == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	if vmr == nil {
+		return errors.New(VmRef_Error_Nil)
+	}
+	stopped, err := waitUntil(deadline, interval, func() (bool, error) {
 		vmState, err := client.GetVmState(vmr)
 		if err != nil {
-			log.Print("Wait error:")
-			log.Println(err)
-		} else if vmState["status"] == "stopped" {
-			return nil
+			return false, err
 		}
-		time.Sleep(5 * time.Second)
+		return vmState["status"] == "stopped", nil
+	})
+	if stopped {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("not shutdown within wait time, last error: %v", err)
 	}
 	return fmt.Errorf("not shutdown within wait time")
 }
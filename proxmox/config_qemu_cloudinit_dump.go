@@ -0,0 +1,154 @@
+package proxmox
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Returns the cloud-init data Proxmox generated for the guest, as it will be presented to the guest.
+func (vmr *VmRef) CloudInitDump(c *Client, dumpType CloudInitDumpType) (string, error) {
+	if c == nil {
+		return "", errors.New(Client_Error_Nil)
+	}
+	if err := dumpType.Validate(); err != nil {
+		return "", err
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return "", err
+	}
+	return vmr.cloudInitDump(c, dumpType)
+}
+
+func (vmr *VmRef) cloudInitDump(c *Client, dumpType CloudInitDumpType) (string, error) {
+	return c.GetItemConfigString(vmr.cloudInitUrl()+"/dump?type="+string(dumpType), "cloud-init", string(dumpType)+" data")
+}
+
+// Returns the user, network and meta data Proxmox generated for the guest.
+func (vmr *VmRef) CloudInitDumpAll(c *Client) (*CloudInitDump, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return nil, err
+	}
+	dump := CloudInitDump{}
+	var err error
+	if dump.User, err = vmr.cloudInitDump(c, CloudInitDumpType_User); err != nil {
+		return nil, err
+	}
+	if dump.Network, err = vmr.cloudInitDump(c, CloudInitDumpType_Network); err != nil {
+		return nil, err
+	}
+	if dump.Meta, err = vmr.cloudInitDump(c, CloudInitDumpType_Meta); err != nil {
+		return nil, err
+	}
+	return &dump, nil
+}
+
+// Returns the cloud-init settings of the guest that have pending changes.
+// Pending changes are applied by regenerating the cloud-init drive.
+func (vmr *VmRef) CloudInitPending(c *Client) ([]CloudInitPendingChange, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return nil, err
+	}
+	changes, err := c.GetItemConfigInterfaceArray(vmr.cloudInitUrl(), "cloud-init", "pending changes")
+	if err != nil {
+		return nil, err
+	}
+	return CloudInitPendingChange{}.mapToSDK(changes), nil
+}
+
+// Regenerates the cloud-init drive of the guest, this applies all pending cloud-init changes.
+func (vmr *VmRef) CloudInitRegenerate(c *Client) error {
+	if c == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return err
+	}
+	return c.Put(nil, vmr.cloudInitUrl())
+}
+
+func (vmr *VmRef) cloudInitUrl() string {
+	return "/nodes/" + vmr.node + "/qemu/" + strconv.Itoa(vmr.vmId) + "/cloudinit"
+}
+
+// The cloud-init data Proxmox generated for a guest.
+type CloudInitDump struct {
+	User    string `json:"user"`
+	Network string `json:"network"`
+	Meta    string `json:"meta"`
+}
+
+type CloudInitDumpType string // enum
+
+const (
+	CloudInitDumpType_Meta    CloudInitDumpType = "meta"
+	CloudInitDumpType_Network CloudInitDumpType = "network"
+	CloudInitDumpType_User    CloudInitDumpType = "user"
+
+	CloudInitDumpType_Error_Invalid string = "cloud-init dump type should be one of [meta, network, user]"
+)
+
+func (dumpType CloudInitDumpType) Validate() error {
+	switch dumpType {
+	case CloudInitDumpType_Meta, CloudInitDumpType_Network, CloudInitDumpType_User:
+		return nil
+	}
+	return errors.New(CloudInitDumpType_Error_Invalid)
+}
+
+// A cloud-init setting of which the active value differs from the configured value.
+type CloudInitPendingChange struct {
+	Key     string  `json:"key"`
+	Value   *string `json:"value,omitempty"`   // the value currently on the cloud-init drive, nil when not set
+	Pending *string `json:"pending,omitempty"` // the value that will be applied, nil when the setting is unchanged or removed
+	Delete  bool    `json:"delete,omitempty"`  // the setting will be removed
+}
+
+// Only returns the settings that have a pending change.
+func (CloudInitPendingChange) mapToSDK(params []interface{}) []CloudInitPendingChange {
+	changes := make([]CloudInitPendingChange, 0, len(params))
+	for _, e := range params {
+		tmpParams, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		change := CloudInitPendingChange{}
+		if v, isSet := tmpParams["key"]; isSet {
+			change.Key = v.(string)
+		}
+		if v, isSet := tmpParams["value"]; isSet {
+			change.Value = cloudInitPendingValue(v)
+		}
+		if v, isSet := tmpParams["pending"]; isSet {
+			change.Pending = cloudInitPendingValue(v)
+		}
+		if v, isSet := tmpParams["delete"]; isSet {
+			change.Delete = apiBool(v)
+		}
+		if change.Pending == nil && !change.Delete {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func cloudInitPendingValue(value interface{}) *string {
+	var tmp string
+	switch v := value.(type) {
+	case string:
+		tmp = v
+	case float64:
+		tmp = strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		tmp = strconv.FormatBool(v)
+	default:
+		return nil
+	}
+	return &tmp
+}
@@ -0,0 +1,60 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CloudInitDumpType_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  CloudInitDumpType
+		output error
+	}{
+		{name: `Valid meta`, input: CloudInitDumpType_Meta},
+		{name: `Valid network`, input: CloudInitDumpType_Network},
+		{name: `Valid user`, input: CloudInitDumpType_User},
+		{name: `Invalid empty`, output: errors.New(CloudInitDumpType_Error_Invalid)},
+		{name: `Invalid vendor`, input: "vendor", output: errors.New(CloudInitDumpType_Error_Invalid)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
+
+func Test_CloudInitPendingChange_mapToSDK(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []interface{}
+		output []CloudInitPendingChange
+	}{
+		{name: `Empty`,
+			output: []CloudInitPendingChange{}},
+		{name: `Unchanged`,
+			input: []interface{}{
+				map[string]interface{}{"key": "ciuser", "value": "root"}},
+			output: []CloudInitPendingChange{}},
+		{name: `Changed`,
+			input: []interface{}{
+				map[string]interface{}{"key": "ciuser", "value": "root", "pending": "admin"},
+				map[string]interface{}{"key": "ipconfig0", "pending": "ip=dhcp"},
+				map[string]interface{}{"key": "ciupgrade", "value": float64(1), "pending": float64(0)},
+				map[string]interface{}{"key": "nameserver", "value": "1.1.1.1", "delete": float64(1)},
+				map[string]interface{}{"key": "searchdomain", "value": "example.com"}},
+			output: []CloudInitPendingChange{
+				{Key: "ciuser", Value: util.Pointer("root"), Pending: util.Pointer("admin")},
+				{Key: "ipconfig0", Pending: util.Pointer("ip=dhcp")},
+				{Key: "ciupgrade", Value: util.Pointer("1"), Pending: util.Pointer("0")},
+				{Key: "nameserver", Value: util.Pointer("1.1.1.1"), Delete: true}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, CloudInitPendingChange{}.mapToSDK(test.input))
+		})
+	}
+}
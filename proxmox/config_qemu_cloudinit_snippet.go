@@ -0,0 +1,638 @@
+package proxmox
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/netip"
+	"strconv"
+)
+
+// Renders cloud-init user-data and network-config, uploads them as snippets and references them in the cloud-init configuration.
+// Proxmox has to allow uploading snippets to the storage for this to work.
+type CloudInitSnippets struct {
+	Storage   string                  `json:"storage"`              // storage with content type snippets. TODO custom type (storage)
+	Name      string                  `json:"name"`                 // prefix of the uploaded files, "<name>-user.yaml" and "<name>-network.yaml"
+	User      *CloudInitUserData      `json:"user,omitempty"`       // Optional
+	NetworkV1 *CloudInitNetworkDataV1 `json:"network_v1,omitempty"` // Optional, mutually exclusive with NetworkV2
+	NetworkV2 *CloudInitNetworkDataV2 `json:"network_v2,omitempty"` // Optional, mutually exclusive with NetworkV1
+}
+
+const (
+	CloudInitSnippets_Error_MutuallyExclusive string = "networkV1 and networkV2 are mutually exclusive"
+	CloudInitSnippets_Error_NameEmpty         string = "name may not be empty"
+	CloudInitSnippets_Error_NoContent         string = "at least one of user, networkV1 or networkV2 should be specified"
+	CloudInitSnippets_Error_StorageEmpty      string = "storage may not be empty"
+)
+
+func (snippets CloudInitSnippets) networkPath() CloudInitSnippetPath {
+	return CloudInitSnippetPath(snippets.Name + "-network.yaml")
+}
+
+func (snippets CloudInitSnippets) userPath() CloudInitSnippetPath {
+	return CloudInitSnippetPath(snippets.Name + "-user.yaml")
+}
+
+// Renders all specified snippets, keyed by their file name.
+func (snippets CloudInitSnippets) render() (map[CloudInitSnippetPath][]byte, error) {
+	files := make(map[CloudInitSnippetPath][]byte)
+	if snippets.User != nil {
+		data, err := snippets.User.Render()
+		if err != nil {
+			return nil, err
+		}
+		files[snippets.userPath()] = data
+	}
+	if snippets.NetworkV1 != nil {
+		data, err := snippets.NetworkV1.Render()
+		if err != nil {
+			return nil, err
+		}
+		files[snippets.networkPath()] = data
+	}
+	if snippets.NetworkV2 != nil {
+		data, err := snippets.NetworkV2.Render()
+		if err != nil {
+			return nil, err
+		}
+		files[snippets.networkPath()] = data
+	}
+	return files, nil
+}
+
+// Returns the cloud-init custom configuration that references the snippets.
+// References in current that are not replaced by the snippets are kept.
+func (snippets CloudInitSnippets) mapToCustom(current *CloudInitCustom) *CloudInitCustom {
+	custom := CloudInitCustom{}
+	if current != nil {
+		custom.Meta = current.Meta
+		custom.Network = current.Network
+		custom.User = current.User
+		custom.Vendor = current.Vendor
+	}
+	if snippets.User != nil {
+		custom.User = &CloudInitSnippet{
+			Storage:  snippets.Storage,
+			FilePath: "snippets/" + snippets.userPath()}
+	}
+	if snippets.NetworkV1 != nil || snippets.NetworkV2 != nil {
+		custom.Network = &CloudInitSnippet{
+			Storage:  snippets.Storage,
+			FilePath: "snippets/" + snippets.networkPath()}
+	}
+	return &custom
+}
+
+// Renders the snippets, uploads them to the storage on the node and returns the cloud-init custom configuration referencing them.
+func (snippets CloudInitSnippets) Upload(c *Client, node string) (*CloudInitCustom, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if err := snippets.Validate(); err != nil {
+		return nil, err
+	}
+	return snippets.upload(c, node, nil)
+}
+
+func (snippets CloudInitSnippets) upload(c *Client, node string, current *CloudInitCustom) (*CloudInitCustom, error) {
+	files, err := snippets.render()
+	if err != nil {
+		return nil, err
+	}
+	for path, data := range files {
+		if err = c.Upload(node, snippets.Storage, string(ContentType_Snippets), string(path), bytes.NewReader(data)); err != nil {
+			return nil, err
+		}
+	}
+	return snippets.mapToCustom(current), nil
+}
+
+func (snippets CloudInitSnippets) Validate() error {
+	if snippets.Storage == "" {
+		return errors.New(CloudInitSnippets_Error_StorageEmpty)
+	}
+	if snippets.Name == "" {
+		return errors.New(CloudInitSnippets_Error_NameEmpty)
+	}
+	if err := snippets.userPath().Validate(); err != nil {
+		return err
+	}
+	if snippets.User == nil && snippets.NetworkV1 == nil && snippets.NetworkV2 == nil {
+		return errors.New(CloudInitSnippets_Error_NoContent)
+	}
+	if snippets.NetworkV1 != nil && snippets.NetworkV2 != nil {
+		return errors.New(CloudInitSnippets_Error_MutuallyExclusive)
+	}
+	if snippets.NetworkV1 != nil {
+		if err := snippets.NetworkV1.Validate(); err != nil {
+			return err
+		}
+	}
+	if snippets.NetworkV2 != nil {
+		return snippets.NetworkV2.Validate()
+	}
+	return nil
+}
+
+// Uploads the snippets to the storage on the node and references them in the Custom section of the cloud-init configuration.
+// Existing references to meta and vendor snippets are kept.
+func (config *CloudInit) UploadSnippets(c *Client, node string, snippets CloudInitSnippets) error {
+	if c == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	if err := snippets.Validate(); err != nil {
+		return err
+	}
+	custom, err := snippets.upload(c, node, config.Custom)
+	if err != nil {
+		return err
+	}
+	config.Custom = custom
+	return nil
+}
+
+// cloud-init user-data in the cloud-config format.
+type CloudInitUserData struct {
+	Hostname       string                 `json:"hostname,omitempty"`
+	FQDN           string                 `json:"fqdn,omitempty"`
+	ManageEtcHosts *bool                  `json:"manage_etc_hosts,omitempty"`
+	Timezone       string                 `json:"timezone,omitempty"`
+	Locale         string                 `json:"locale,omitempty"`
+	Users          []CloudInitUser        `json:"users,omitempty"`
+	SshPwauth      *bool                  `json:"ssh_pwauth,omitempty"`
+	PackageUpdate  *bool                  `json:"package_update,omitempty"`
+	PackageUpgrade *bool                  `json:"package_upgrade,omitempty"`
+	Packages       []string               `json:"packages,omitempty"`
+	WriteFiles     []CloudInitWriteFile   `json:"write_files,omitempty"`
+	BootCmd        []string               `json:"bootcmd,omitempty"`
+	RunCmd         []string               `json:"runcmd,omitempty"`
+	FinalMessage   string                 `json:"final_message,omitempty"`
+	PowerState     *CloudInitPowerState   `json:"power_state,omitempty"`
+	Extra          map[string]interface{} `json:"-"` // additional top level cloud-config modules, these take precedence over the typed fields
+}
+
+// Renders the user-data as cloud-config.
+// The body is JSON, which is valid YAML and understood by cloud-init.
+func (data CloudInitUserData) Render() ([]byte, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	config := map[string]interface{}{}
+	if err = json.Unmarshal(raw, &config); err != nil {
+		return nil, err
+	}
+	for k, v := range data.Extra {
+		config[k] = v
+	}
+	body, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte("#cloud-config\n"), append(body, '\n')...), nil
+}
+
+type CloudInitUser struct {
+	Name              string   `json:"name"`
+	Gecos             string   `json:"gecos,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+	Shell             string   `json:"shell,omitempty"`
+	Sudo              string   `json:"sudo,omitempty"` // e.g. "ALL=(ALL) NOPASSWD:ALL"
+	LockPassword      *bool    `json:"lock_passwd,omitempty"`
+	HashedPassword    string   `json:"hashed_passwd,omitempty"`
+	SshAuthorizedKeys []string `json:"ssh_authorized_keys,omitempty"`
+}
+
+type CloudInitWriteFile struct {
+	Path        string `json:"path"`
+	Content     string `json:"content,omitempty"`
+	Encoding    string `json:"encoding,omitempty"` // e.g. "b64" when Content is base64 encoded
+	Owner       string `json:"owner,omitempty"`    // e.g. "root:root"
+	Permissions string `json:"permissions,omitempty"`
+	Append      bool   `json:"append,omitempty"`
+}
+
+type CloudInitPowerState struct {
+	Mode    string `json:"mode"`              // poweroff, reboot or halt
+	Delay   string `json:"delay,omitempty"`   // e.g. "now" or "+5"
+	Message string `json:"message,omitempty"` // Optional
+	Timeout uint   `json:"timeout,omitempty"` // seconds to wait for cloud-init to finish
+}
+
+// cloud-init network-config version 1.
+type CloudInitNetworkDataV1 struct {
+	Interfaces    []CloudInitNetworkV1Interface `json:"interfaces"`
+	NameServers   []netip.Addr                  `json:"nameservers,omitempty"`
+	SearchDomains []string                      `json:"search_domains,omitempty"`
+}
+
+const CloudInitNetworkDataV1_Error_NoInterfaces string = "at least one interface should be specified"
+
+func (network CloudInitNetworkDataV1) mapToAPI() map[string]interface{} {
+	config := make([]interface{}, 0, len(network.Interfaces)+1)
+	for _, e := range network.Interfaces {
+		config = append(config, e.mapToAPI())
+	}
+	if len(network.NameServers) > 0 || len(network.SearchDomains) > 0 {
+		nameserver := map[string]interface{}{"type": "nameserver"}
+		if len(network.NameServers) > 0 {
+			nameserver["address"] = network.NameServers
+		}
+		if len(network.SearchDomains) > 0 {
+			nameserver["search"] = network.SearchDomains
+		}
+		config = append(config, nameserver)
+	}
+	return map[string]interface{}{
+		"version": 1,
+		"config":  config}
+}
+
+// Renders the network-config, the body is JSON which is valid YAML and understood by cloud-init.
+func (network CloudInitNetworkDataV1) Render() ([]byte, error) {
+	body, err := json.MarshalIndent(network.mapToAPI(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(body, '\n'), nil
+}
+
+func (network CloudInitNetworkDataV1) Validate() error {
+	if len(network.Interfaces) == 0 {
+		return errors.New(CloudInitNetworkDataV1_Error_NoInterfaces)
+	}
+	for _, e := range network.Interfaces {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A physical interface in network-config version 1.
+type CloudInitNetworkV1Interface struct {
+	Name       string                     `json:"name"`
+	MacAddress net.HardwareAddr           `json:"mac_address,omitempty"`
+	MTU        uint                       `json:"mtu,omitempty"`
+	Subnets    []CloudInitNetworkV1Subnet `json:"subnets,omitempty"`
+}
+
+const CloudInitNetworkV1Interface_Error_NameEmpty string = "interface name may not be empty"
+
+func (iFace CloudInitNetworkV1Interface) mapToAPI() map[string]interface{} {
+	params := map[string]interface{}{
+		"type": "physical",
+		"name": iFace.Name}
+	if len(iFace.MacAddress) > 0 {
+		params["mac_address"] = iFace.MacAddress.String()
+	}
+	if iFace.MTU != 0 {
+		params["mtu"] = iFace.MTU
+	}
+	if len(iFace.Subnets) > 0 {
+		subnets := make([]interface{}, len(iFace.Subnets))
+		for i, e := range iFace.Subnets {
+			subnets[i] = e.mapToAPI()
+		}
+		params["subnets"] = subnets
+	}
+	return params
+}
+
+func (iFace CloudInitNetworkV1Interface) Validate() error {
+	if iFace.Name == "" {
+		return errors.New(CloudInitNetworkV1Interface_Error_NameEmpty)
+	}
+	for _, e := range iFace.Subnets {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type CloudInitNetworkV1Subnet struct {
+	Type    CloudInitNetworkV1SubnetType `json:"type"`
+	Address netip.Prefix                 `json:"address,omitempty"` // only for static and static6
+	Gateway netip.Addr                   `json:"gateway,omitempty"` // only for static and static6
+}
+
+const (
+	CloudInitNetworkV1Subnet_Error_AddressRequired string = "address is required for static subnets"
+	CloudInitNetworkV1Subnet_Error_AddressFamily   string = "address family does not match the subnet type"
+	CloudInitNetworkV1Subnet_Error_NotStatic       string = "address and gateway may only be set for static subnets"
+)
+
+func (subnet CloudInitNetworkV1Subnet) mapToAPI() map[string]interface{} {
+	params := map[string]interface{}{"type": string(subnet.Type)}
+	if subnet.Address.IsValid() {
+		params["address"] = subnet.Address.String()
+	}
+	if subnet.Gateway.IsValid() {
+		params["gateway"] = subnet.Gateway.String()
+	}
+	return params
+}
+
+func (subnet CloudInitNetworkV1Subnet) Validate() error {
+	if err := subnet.Type.Validate(); err != nil {
+		return err
+	}
+	switch subnet.Type {
+	case CloudInitNetworkV1SubnetType_Static, CloudInitNetworkV1SubnetType_Static6:
+		if !subnet.Address.IsValid() {
+			return errors.New(CloudInitNetworkV1Subnet_Error_AddressRequired)
+		}
+		ipv4 := subnet.Type == CloudInitNetworkV1SubnetType_Static
+		if subnet.Address.Addr().Is4() != ipv4 || (subnet.Gateway.IsValid() && subnet.Gateway.Is4() != ipv4) {
+			return errors.New(CloudInitNetworkV1Subnet_Error_AddressFamily)
+		}
+	default:
+		if subnet.Address.IsValid() || subnet.Gateway.IsValid() {
+			return errors.New(CloudInitNetworkV1Subnet_Error_NotStatic)
+		}
+	}
+	return nil
+}
+
+type CloudInitNetworkV1SubnetType string // enum
+
+const (
+	CloudInitNetworkV1SubnetType_Dhcp4         CloudInitNetworkV1SubnetType = "dhcp4"
+	CloudInitNetworkV1SubnetType_Dhcp6         CloudInitNetworkV1SubnetType = "dhcp6"
+	CloudInitNetworkV1SubnetType_Slaac         CloudInitNetworkV1SubnetType = "ipv6_slaac"
+	CloudInitNetworkV1SubnetType_Static        CloudInitNetworkV1SubnetType = "static"
+	CloudInitNetworkV1SubnetType_Static6       CloudInitNetworkV1SubnetType = "static6"
+	CloudInitNetworkV1SubnetType_Error_Invalid string                       = "subnet type should be one of [dhcp4, dhcp6, ipv6_slaac, static, static6]"
+)
+
+func (t CloudInitNetworkV1SubnetType) Validate() error {
+	switch t {
+	case CloudInitNetworkV1SubnetType_Dhcp4, CloudInitNetworkV1SubnetType_Dhcp6, CloudInitNetworkV1SubnetType_Slaac,
+		CloudInitNetworkV1SubnetType_Static, CloudInitNetworkV1SubnetType_Static6:
+		return nil
+	}
+	return errors.New(CloudInitNetworkV1SubnetType_Error_Invalid)
+}
+
+// cloud-init network-config version 2, the netplan format.
+// The keys of the maps are the names of the interfaces.
+type CloudInitNetworkDataV2 struct {
+	Ethernets map[string]CloudInitNetworkV2Ethernet `json:"ethernets,omitempty"`
+	Bonds     map[string]CloudInitNetworkV2Bond     `json:"bonds,omitempty"`
+	Bridges   map[string]CloudInitNetworkV2Bridge   `json:"bridges,omitempty"`
+	Vlans     map[string]CloudInitNetworkV2Vlan     `json:"vlans,omitempty"`
+}
+
+const (
+	CloudInitNetworkDataV2_Error_NoInterfaces string = "at least one interface should be specified"
+	CloudInitNetworkDataV2_Error_NameEmpty    string = "interface name may not be empty"
+)
+
+func (network CloudInitNetworkDataV2) mapToAPI() map[string]interface{} {
+	params := map[string]interface{}{"version": 2}
+	if len(network.Ethernets) > 0 {
+		ethernets := make(map[string]interface{}, len(network.Ethernets))
+		for name, e := range network.Ethernets {
+			ethernets[name] = e.mapToAPI()
+		}
+		params["ethernets"] = ethernets
+	}
+	if len(network.Bonds) > 0 {
+		bonds := make(map[string]interface{}, len(network.Bonds))
+		for name, e := range network.Bonds {
+			bonds[name] = e.mapToAPI()
+		}
+		params["bonds"] = bonds
+	}
+	if len(network.Bridges) > 0 {
+		bridges := make(map[string]interface{}, len(network.Bridges))
+		for name, e := range network.Bridges {
+			bridges[name] = e.mapToAPI()
+		}
+		params["bridges"] = bridges
+	}
+	if len(network.Vlans) > 0 {
+		vlans := make(map[string]interface{}, len(network.Vlans))
+		for name, e := range network.Vlans {
+			vlans[name] = e.mapToAPI()
+		}
+		params["vlans"] = vlans
+	}
+	return params
+}
+
+// Renders the network-config, the body is JSON which is valid YAML and understood by cloud-init.
+func (network CloudInitNetworkDataV2) Render() ([]byte, error) {
+	body, err := json.MarshalIndent(network.mapToAPI(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(body, '\n'), nil
+}
+
+func (network CloudInitNetworkDataV2) Validate() error {
+	if len(network.Ethernets)+len(network.Bonds)+len(network.Bridges)+len(network.Vlans) == 0 {
+		return errors.New(CloudInitNetworkDataV2_Error_NoInterfaces)
+	}
+	for name, e := range network.Ethernets {
+		if name == "" {
+			return errors.New(CloudInitNetworkDataV2_Error_NameEmpty)
+		}
+		if err := e.Interface.Validate(); err != nil {
+			return err
+		}
+	}
+	for name, e := range network.Bonds {
+		if name == "" {
+			return errors.New(CloudInitNetworkDataV2_Error_NameEmpty)
+		}
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	for name, e := range network.Bridges {
+		if name == "" {
+			return errors.New(CloudInitNetworkDataV2_Error_NameEmpty)
+		}
+		if err := e.Interface.Validate(); err != nil {
+			return err
+		}
+	}
+	for name, e := range network.Vlans {
+		if name == "" {
+			return errors.New(CloudInitNetworkDataV2_Error_NameEmpty)
+		}
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Settings shared by all interface kinds of network-config version 2.
+type CloudInitNetworkV2Interface struct {
+	DHCP4         bool                      `json:"dhcp4,omitempty"`
+	DHCP6         bool                      `json:"dhcp6,omitempty"`
+	Addresses     []netip.Prefix            `json:"addresses,omitempty"`
+	Routes        []CloudInitNetworkV2Route `json:"routes,omitempty"`
+	NameServers   []netip.Addr              `json:"nameservers,omitempty"`
+	SearchDomains []string                  `json:"search_domains,omitempty"`
+	MTU           uint                      `json:"mtu,omitempty"`
+}
+
+func (iFace CloudInitNetworkV2Interface) mapToAPI(params map[string]interface{}) {
+	if iFace.DHCP4 {
+		params["dhcp4"] = true
+	}
+	if iFace.DHCP6 {
+		params["dhcp6"] = true
+	}
+	if len(iFace.Addresses) > 0 {
+		addresses := make([]string, len(iFace.Addresses))
+		for i, e := range iFace.Addresses {
+			addresses[i] = e.String()
+		}
+		params["addresses"] = addresses
+	}
+	if len(iFace.Routes) > 0 {
+		routes := make([]interface{}, len(iFace.Routes))
+		for i, e := range iFace.Routes {
+			routes[i] = e.mapToAPI()
+		}
+		params["routes"] = routes
+	}
+	if len(iFace.NameServers) > 0 || len(iFace.SearchDomains) > 0 {
+		nameservers := map[string]interface{}{}
+		if len(iFace.NameServers) > 0 {
+			nameservers["addresses"] = iFace.NameServers
+		}
+		if len(iFace.SearchDomains) > 0 {
+			nameservers["search"] = iFace.SearchDomains
+		}
+		params["nameservers"] = nameservers
+	}
+	if iFace.MTU != 0 {
+		params["mtu"] = iFace.MTU
+	}
+}
+
+func (iFace CloudInitNetworkV2Interface) Validate() error {
+	for _, e := range iFace.Routes {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type CloudInitNetworkV2Route struct {
+	To     netip.Prefix `json:"to"`
+	Via    netip.Addr   `json:"via"`
+	Metric uint         `json:"metric,omitempty"`
+}
+
+const CloudInitNetworkV2Route_Error_Invalid string = "route requires a valid destination and gateway of the same address family"
+
+func (route CloudInitNetworkV2Route) mapToAPI() map[string]interface{} {
+	params := map[string]interface{}{
+		"to":  route.To.String(),
+		"via": route.Via.String()}
+	if route.Metric != 0 {
+		params["metric"] = route.Metric
+	}
+	return params
+}
+
+func (route CloudInitNetworkV2Route) Validate() error {
+	if !route.To.IsValid() || !route.Via.IsValid() || route.To.Addr().Is4() != route.Via.Is4() {
+		return errors.New(CloudInitNetworkV2Route_Error_Invalid)
+	}
+	return nil
+}
+
+type CloudInitNetworkV2Ethernet struct {
+	MacAddress net.HardwareAddr `json:"mac_address,omitempty"` // match the interface by mac address instead of name
+	SetName    string           `json:"set_name,omitempty"`    // rename the matched interface
+	Interface  CloudInitNetworkV2Interface
+}
+
+func (ethernet CloudInitNetworkV2Ethernet) mapToAPI() map[string]interface{} {
+	params := map[string]interface{}{}
+	if len(ethernet.MacAddress) > 0 {
+		params["match"] = map[string]interface{}{"macaddress": ethernet.MacAddress.String()}
+	}
+	if ethernet.SetName != "" {
+		params["set-name"] = ethernet.SetName
+	}
+	ethernet.Interface.mapToAPI(params)
+	return params
+}
+
+type CloudInitNetworkV2Bond struct {
+	Interfaces []string `json:"interfaces"`
+	Mode       string   `json:"mode,omitempty"` // e.g. active-backup, 802.3ad, balance-rr
+	Interface  CloudInitNetworkV2Interface
+}
+
+const CloudInitNetworkV2Bond_Error_NoInterfaces string = "bond requires at least one interface"
+
+func (bond CloudInitNetworkV2Bond) mapToAPI() map[string]interface{} {
+	params := map[string]interface{}{"interfaces": bond.Interfaces}
+	if bond.Mode != "" {
+		params["parameters"] = map[string]interface{}{"mode": bond.Mode}
+	}
+	bond.Interface.mapToAPI(params)
+	return params
+}
+
+func (bond CloudInitNetworkV2Bond) Validate() error {
+	if len(bond.Interfaces) == 0 {
+		return errors.New(CloudInitNetworkV2Bond_Error_NoInterfaces)
+	}
+	return bond.Interface.Validate()
+}
+
+type CloudInitNetworkV2Bridge struct {
+	Interfaces []string `json:"interfaces,omitempty"`
+	Interface  CloudInitNetworkV2Interface
+}
+
+func (bridge CloudInitNetworkV2Bridge) mapToAPI() map[string]interface{} {
+	params := map[string]interface{}{}
+	if len(bridge.Interfaces) > 0 {
+		params["interfaces"] = bridge.Interfaces
+	}
+	bridge.Interface.mapToAPI(params)
+	return params
+}
+
+type CloudInitNetworkV2Vlan struct {
+	ID        uint   `json:"id"`
+	Link      string `json:"link"`
+	Interface CloudInitNetworkV2Interface
+}
+
+const (
+	CloudInitNetworkV2Vlan_Error_ID   string = "vlan id should be in the range 1-4094"
+	CloudInitNetworkV2Vlan_Error_Link string = "vlan link may not be empty"
+)
+
+func (vlan CloudInitNetworkV2Vlan) mapToAPI() map[string]interface{} {
+	params := map[string]interface{}{
+		"id":   vlan.ID,
+		"link": vlan.Link}
+	vlan.Interface.mapToAPI(params)
+	return params
+}
+
+func (vlan CloudInitNetworkV2Vlan) Validate() error {
+	if vlan.ID < 1 || vlan.ID > 4094 {
+		return errors.New(CloudInitNetworkV2Vlan_Error_ID + ", got " + strconv.FormatUint(uint64(vlan.ID), 10))
+	}
+	if vlan.Link == "" {
+		return errors.New(CloudInitNetworkV2Vlan_Error_Link)
+	}
+	return vlan.Interface.Validate()
+}
@@ -0,0 +1,332 @@
+package proxmox
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CloudInitSnippets_mapToCustom(t *testing.T) {
+	meta := &CloudInitSnippet{Storage: "local", FilePath: "snippets/meta.yaml"}
+	vendor := &CloudInitSnippet{Storage: "local", FilePath: "snippets/vendor.yaml"}
+	tests := []struct {
+		name     string
+		snippets CloudInitSnippets
+		current  *CloudInitCustom
+		output   *CloudInitCustom
+	}{
+		{name: `User only`,
+			snippets: CloudInitSnippets{Storage: "local", Name: "vm100", User: &CloudInitUserData{}},
+			output:   &CloudInitCustom{User: &CloudInitSnippet{Storage: "local", FilePath: "snippets/vm100-user.yaml"}}},
+		{name: `Network v1`,
+			snippets: CloudInitSnippets{Storage: "local", Name: "vm100", NetworkV1: &CloudInitNetworkDataV1{}},
+			output:   &CloudInitCustom{Network: &CloudInitSnippet{Storage: "local", FilePath: "snippets/vm100-network.yaml"}}},
+		{name: `Network v2, keep current`,
+			snippets: CloudInitSnippets{Storage: "nfs", Name: "vm100", NetworkV2: &CloudInitNetworkDataV2{}},
+			current: &CloudInitCustom{
+				Meta:   meta,
+				User:   &CloudInitSnippet{Storage: "local", FilePath: "snippets/user.yaml"},
+				Vendor: vendor},
+			output: &CloudInitCustom{
+				Meta:    meta,
+				Network: &CloudInitSnippet{Storage: "nfs", FilePath: "snippets/vm100-network.yaml"},
+				User:    &CloudInitSnippet{Storage: "local", FilePath: "snippets/user.yaml"},
+				Vendor:  vendor}},
+		{name: `Replace current`,
+			snippets: CloudInitSnippets{Storage: "local", Name: "vm100", User: &CloudInitUserData{}, NetworkV1: &CloudInitNetworkDataV1{}},
+			current: &CloudInitCustom{
+				Meta:    meta,
+				Network: &CloudInitSnippet{Storage: "local", FilePath: "snippets/network.yaml"},
+				User:    &CloudInitSnippet{Storage: "local", FilePath: "snippets/user.yaml"}},
+			output: &CloudInitCustom{
+				Meta:    meta,
+				Network: &CloudInitSnippet{Storage: "local", FilePath: "snippets/vm100-network.yaml"},
+				User:    &CloudInitSnippet{Storage: "local", FilePath: "snippets/vm100-user.yaml"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := test.snippets.mapToCustom(test.current)
+			require.Equal(t, test.output, output)
+			require.NoError(t, output.Validate())
+		})
+	}
+}
+
+func Test_CloudInitSnippets_Validate(t *testing.T) {
+	validV1 := &CloudInitNetworkDataV1{Interfaces: []CloudInitNetworkV1Interface{{Name: "eth0"}}}
+	validV2 := &CloudInitNetworkDataV2{Ethernets: map[string]CloudInitNetworkV2Ethernet{"eth0": {}}}
+	tests := []struct {
+		name   string
+		input  CloudInitSnippets
+		output error
+	}{
+		{name: `Valid User`,
+			input: CloudInitSnippets{Storage: "local", Name: "vm100", User: &CloudInitUserData{}}},
+		{name: `Valid User and NetworkV1`,
+			input: CloudInitSnippets{Storage: "local", Name: "vm100", User: &CloudInitUserData{}, NetworkV1: validV1}},
+		{name: `Valid NetworkV2`,
+			input: CloudInitSnippets{Storage: "local", Name: "vm100", NetworkV2: validV2}},
+		{name: `Invalid Storage empty`,
+			input:  CloudInitSnippets{Name: "vm100", User: &CloudInitUserData{}},
+			output: errors.New(CloudInitSnippets_Error_StorageEmpty)},
+		{name: `Invalid Name empty`,
+			input:  CloudInitSnippets{Storage: "local", User: &CloudInitUserData{}},
+			output: errors.New(CloudInitSnippets_Error_NameEmpty)},
+		{name: `Invalid Name characters`,
+			input:  CloudInitSnippets{Storage: "local", Name: "vm!100", User: &CloudInitUserData{}},
+			output: errors.New(CloudInitSnippetPath_Error_InvalidCharacters)},
+		{name: `Invalid no content`,
+			input:  CloudInitSnippets{Storage: "local", Name: "vm100"},
+			output: errors.New(CloudInitSnippets_Error_NoContent)},
+		{name: `Invalid NetworkV1 and NetworkV2`,
+			input:  CloudInitSnippets{Storage: "local", Name: "vm100", NetworkV1: validV1, NetworkV2: validV2},
+			output: errors.New(CloudInitSnippets_Error_MutuallyExclusive)},
+		{name: `Invalid NetworkV1`,
+			input:  CloudInitSnippets{Storage: "local", Name: "vm100", NetworkV1: &CloudInitNetworkDataV1{}},
+			output: errors.New(CloudInitNetworkDataV1_Error_NoInterfaces)},
+		{name: `Invalid NetworkV2`,
+			input:  CloudInitSnippets{Storage: "local", Name: "vm100", NetworkV2: &CloudInitNetworkDataV2{}},
+			output: errors.New(CloudInitNetworkDataV2_Error_NoInterfaces)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
+
+func Test_CloudInitUserData_Render(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  CloudInitUserData
+		output string
+	}{
+		{name: `Empty`,
+			output: "#cloud-config\n{}\n"},
+		{name: `Full`,
+			input: CloudInitUserData{
+				Hostname:       "test",
+				ManageEtcHosts: util.Pointer(true),
+				Users: []CloudInitUser{{
+					Name:              "admin",
+					Sudo:              "ALL=(ALL) NOPASSWD:ALL",
+					SshAuthorizedKeys: []string{"ssh-ed25519 AAAA"}}},
+				Packages: []string{"qemu-guest-agent"},
+				RunCmd:   []string{"systemctl enable --now qemu-guest-agent"},
+				Extra:    map[string]interface{}{"hostname": "override", "growpart": map[string]interface{}{"mode": "auto"}}},
+			output: `#cloud-config
+{
+  "growpart": {
+    "mode": "auto"
+  },
+  "hostname": "override",
+  "manage_etc_hosts": true,
+  "packages": [
+    "qemu-guest-agent"
+  ],
+  "runcmd": [
+    "systemctl enable --now qemu-guest-agent"
+  ],
+  "users": [
+    {
+      "name": "admin",
+      "ssh_authorized_keys": [
+        "ssh-ed25519 AAAA"
+      ],
+      "sudo": "ALL=(ALL) NOPASSWD:ALL"
+    }
+  ]
+}
+`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, err := test.input.Render()
+			require.NoError(t, err)
+			require.Equal(t, test.output, string(output))
+		})
+	}
+}
+
+func Test_CloudInitNetworkDataV1_mapToAPI(t *testing.T) {
+	mac, _ := net.ParseMAC("bc:24:11:00:00:01")
+	tests := []struct {
+		name   string
+		input  CloudInitNetworkDataV1
+		output map[string]interface{}
+	}{
+		{name: `Full`,
+			input: CloudInitNetworkDataV1{
+				Interfaces: []CloudInitNetworkV1Interface{{
+					Name:       "eth0",
+					MacAddress: mac,
+					MTU:        1500,
+					Subnets: []CloudInitNetworkV1Subnet{
+						{Type: CloudInitNetworkV1SubnetType_Static,
+							Address: netip.MustParsePrefix("192.168.1.10/24"),
+							Gateway: netip.MustParseAddr("192.168.1.1")},
+						{Type: CloudInitNetworkV1SubnetType_Dhcp6}}}},
+				NameServers:   []netip.Addr{netip.MustParseAddr("1.1.1.1")},
+				SearchDomains: []string{"example.com"}},
+			output: map[string]interface{}{
+				"version": 1,
+				"config": []interface{}{
+					map[string]interface{}{
+						"type":        "physical",
+						"name":        "eth0",
+						"mac_address": "bc:24:11:00:00:01",
+						"mtu":         uint(1500),
+						"subnets": []interface{}{
+							map[string]interface{}{"type": "static", "address": "192.168.1.10/24", "gateway": "192.168.1.1"},
+							map[string]interface{}{"type": "dhcp6"}}},
+					map[string]interface{}{
+						"type":    "nameserver",
+						"address": []netip.Addr{netip.MustParseAddr("1.1.1.1")},
+						"search":  []string{"example.com"}}}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.mapToAPI())
+		})
+	}
+}
+
+func Test_CloudInitNetworkV1Subnet_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  CloudInitNetworkV1Subnet
+		output error
+	}{
+		{name: `Valid dhcp4`,
+			input: CloudInitNetworkV1Subnet{Type: CloudInitNetworkV1SubnetType_Dhcp4}},
+		{name: `Valid static`,
+			input: CloudInitNetworkV1Subnet{Type: CloudInitNetworkV1SubnetType_Static, Address: netip.MustParsePrefix("10.0.0.2/8"), Gateway: netip.MustParseAddr("10.0.0.1")}},
+		{name: `Valid static6`,
+			input: CloudInitNetworkV1Subnet{Type: CloudInitNetworkV1SubnetType_Static6, Address: netip.MustParsePrefix("2001:db8::2/64")}},
+		{name: `Invalid type`,
+			input:  CloudInitNetworkV1Subnet{Type: "dhcp"},
+			output: errors.New(CloudInitNetworkV1SubnetType_Error_Invalid)},
+		{name: `Invalid static no address`,
+			input:  CloudInitNetworkV1Subnet{Type: CloudInitNetworkV1SubnetType_Static},
+			output: errors.New(CloudInitNetworkV1Subnet_Error_AddressRequired)},
+		{name: `Invalid static with IPv6 address`,
+			input:  CloudInitNetworkV1Subnet{Type: CloudInitNetworkV1SubnetType_Static, Address: netip.MustParsePrefix("2001:db8::2/64")},
+			output: errors.New(CloudInitNetworkV1Subnet_Error_AddressFamily)},
+		{name: `Invalid static6 with IPv4 gateway`,
+			input:  CloudInitNetworkV1Subnet{Type: CloudInitNetworkV1SubnetType_Static6, Address: netip.MustParsePrefix("2001:db8::2/64"), Gateway: netip.MustParseAddr("10.0.0.1")},
+			output: errors.New(CloudInitNetworkV1Subnet_Error_AddressFamily)},
+		{name: `Invalid dhcp4 with address`,
+			input:  CloudInitNetworkV1Subnet{Type: CloudInitNetworkV1SubnetType_Dhcp4, Address: netip.MustParsePrefix("10.0.0.2/8")},
+			output: errors.New(CloudInitNetworkV1Subnet_Error_NotStatic)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
+
+func Test_CloudInitNetworkDataV2_Render(t *testing.T) {
+	mac, _ := net.ParseMAC("bc:24:11:00:00:01")
+	tests := []struct {
+		name   string
+		input  CloudInitNetworkDataV2
+		output string
+	}{
+		{name: `Ethernet and Vlan`,
+			input: CloudInitNetworkDataV2{
+				Ethernets: map[string]CloudInitNetworkV2Ethernet{"lan": {
+					MacAddress: mac,
+					SetName:    "lan",
+					Interface:  CloudInitNetworkV2Interface{DHCP4: true}}},
+				Vlans: map[string]CloudInitNetworkV2Vlan{"lan.10": {
+					ID:   10,
+					Link: "lan",
+					Interface: CloudInitNetworkV2Interface{
+						Addresses:   []netip.Prefix{netip.MustParsePrefix("10.0.10.2/24")},
+						Routes:      []CloudInitNetworkV2Route{{To: netip.MustParsePrefix("0.0.0.0/0"), Via: netip.MustParseAddr("10.0.10.1")}},
+						NameServers: []netip.Addr{netip.MustParseAddr("10.0.10.1")}}}}},
+			output: `{
+  "ethernets": {
+    "lan": {
+      "dhcp4": true,
+      "match": {
+        "macaddress": "bc:24:11:00:00:01"
+      },
+      "set-name": "lan"
+    }
+  },
+  "version": 2,
+  "vlans": {
+    "lan.10": {
+      "addresses": [
+        "10.0.10.2/24"
+      ],
+      "id": 10,
+      "link": "lan",
+      "nameservers": {
+        "addresses": [
+          "10.0.10.1"
+        ]
+      },
+      "routes": [
+        {
+          "to": "0.0.0.0/0",
+          "via": "10.0.10.1"
+        }
+      ]
+    }
+  }
+}
+`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, err := test.input.Render()
+			require.NoError(t, err)
+			require.Equal(t, test.output, string(output))
+		})
+	}
+}
+
+func Test_CloudInitNetworkDataV2_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  CloudInitNetworkDataV2
+		output error
+	}{
+		{name: `Valid`,
+			input: CloudInitNetworkDataV2{
+				Ethernets: map[string]CloudInitNetworkV2Ethernet{"eth0": {}, "eth1": {}},
+				Bonds:     map[string]CloudInitNetworkV2Bond{"bond0": {Interfaces: []string{"eth0", "eth1"}, Mode: "active-backup"}},
+				Bridges:   map[string]CloudInitNetworkV2Bridge{"br0": {Interfaces: []string{"bond0"}}},
+				Vlans:     map[string]CloudInitNetworkV2Vlan{"br0.20": {ID: 20, Link: "br0"}}}},
+		{name: `Invalid no interfaces`,
+			output: errors.New(CloudInitNetworkDataV2_Error_NoInterfaces)},
+		{name: `Invalid name empty`,
+			input:  CloudInitNetworkDataV2{Bridges: map[string]CloudInitNetworkV2Bridge{"": {}}},
+			output: errors.New(CloudInitNetworkDataV2_Error_NameEmpty)},
+		{name: `Invalid bond no interfaces`,
+			input:  CloudInitNetworkDataV2{Bonds: map[string]CloudInitNetworkV2Bond{"bond0": {}}},
+			output: errors.New(CloudInitNetworkV2Bond_Error_NoInterfaces)},
+		{name: `Invalid route`,
+			input: CloudInitNetworkDataV2{Ethernets: map[string]CloudInitNetworkV2Ethernet{"eth0": {Interface: CloudInitNetworkV2Interface{
+				Routes: []CloudInitNetworkV2Route{{To: netip.MustParsePrefix("::/0"), Via: netip.MustParseAddr("10.0.0.1")}}}}}},
+			output: errors.New(CloudInitNetworkV2Route_Error_Invalid)},
+		{name: `Invalid vlan id`,
+			input:  CloudInitNetworkDataV2{Vlans: map[string]CloudInitNetworkV2Vlan{"eth0.0": {Link: "eth0"}}},
+			output: errors.New(CloudInitNetworkV2Vlan_Error_ID + ", got 0")},
+		{name: `Invalid vlan link`,
+			input:  CloudInitNetworkDataV2{Vlans: map[string]CloudInitNetworkV2Vlan{"eth0.10": {ID: 10}}},
+			output: errors.New(CloudInitNetworkV2Vlan_Error_Link)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
@@ -191,13 +191,14 @@ type qemuDisk struct {
 	Disk       bool // true = disk, false = passthrough
 	EmulateSSD bool // Only set for ide,sata,scsi
 	// TODO custom type
-	File            string         // Only set for Passthrough.
-	fileSyntax      diskSyntaxEnum // private enum to determine the syntax of the file path, as this changes depending on the type of backing storage. ie nfs, lvm, local, etc.
-	Format          QemuDiskFormat // Only set for Disk
-	Id              uint           // Only set for Disk
-	IOThread        bool           // Only set for scsi,virtio
-	LinkedDiskId    *uint          // Only set for Disk
-	ReadOnly        bool           // Only set for scsi,virtio
+	File            string          // Only set for Passthrough.
+	fileSyntax      diskSyntaxEnum  // private enum to determine the syntax of the file path, as this changes depending on the type of backing storage. ie nfs, lvm, local, etc.
+	Format          QemuDiskFormat  // Only set for Disk
+	Id              uint            // Only set for Disk
+	ImportFrom      *QemuDiskImport // Only set for Disk
+	IOThread        bool            // Only set for scsi,virtio
+	LinkedDiskId    *uint           // Only set for Disk
+	ReadOnly        bool            // Only set for scsi,virtio
 	Replicate       bool
 	Serial          QemuDiskSerial
 	SizeInKibibytes QemuDiskSize
@@ -252,7 +253,10 @@ func (disk qemuDisk) formatDisk(vmID, LinkedVmId uint, currentStorage string, cu
 func (disk qemuDisk) mapToApiValues(vmID, LinkedVmId uint, currentStorage string, currentFormat QemuDiskFormat, syntax diskSyntaxEnum, create bool) (settings string) {
 	if disk.Storage != "" {
 		if create {
-			if disk.SizeInKibibytes%gibibyte == 0 {
+			if disk.ImportFrom != nil {
+				// the disk is resized after the import when a size is specified
+				settings = disk.Storage + ":0,import-from=" + disk.ImportFrom.String()
+			} else if disk.SizeInKibibytes%gibibyte == 0 {
 				settings = disk.Storage + ":" + strconv.FormatInt(int64(disk.SizeInKibibytes/gibibyte), 10)
 			} else {
 				settings = disk.Storage + ":0.001"
@@ -526,8 +530,16 @@ func (disk *qemuDisk) validate() (err error) {
 		if err = disk.Format.Validate(); err != nil {
 			return
 		}
-		if err = disk.SizeInKibibytes.Validate(); err != nil {
-			return
+		if disk.ImportFrom != nil {
+			if err = disk.ImportFrom.Validate(); err != nil {
+				return
+			}
+		}
+		// when importing the size is optional
+		if disk.ImportFrom == nil || disk.SizeInKibibytes != 0 {
+			if err = disk.SizeInKibibytes.Validate(); err != nil {
+				return
+			}
 		}
 		if disk.Storage == "" {
 			return errors.New(Error_QemuDisk_Storage)
@@ -786,6 +798,83 @@ func (id QemuDiskId) Validate() error {
 	return errors.New(ERROR_QemuDiskId_Invalid)
 }
 
+// Source of an existing disk image to create a new disk from.
+// Volume and Path are mutually exclusive.
+type QemuDiskImport struct {
+	// Volume id of a disk image, e.g. "local:import/debian-12.qcow2" or "local-lvm:vm-100-disk-0"
+	Volume string `json:"volume,omitempty"`
+	// Absolute path of a disk image on the node, only root@pam may import from a path.
+	Path string `json:"path,omitempty"`
+}
+
+const (
+	Error_QemuDiskImport_ContentType       string = "volume should be of content type images or import"
+	Error_QemuDiskImport_Empty             string = "one of volume or path should be specified"
+	Error_QemuDiskImport_Format            string = "imported disk image should be one of the following formats: qcow2,raw,vmdk"
+	Error_QemuDiskImport_IllegalCharacter  string = "volume and path may not contain a comma"
+	Error_QemuDiskImport_MutuallyExclusive string = "volume and path are mutually exclusive"
+	Error_QemuDiskImport_Path              string = "path should be absolute"
+	Error_QemuDiskImport_Volume            string = "volume should be in the format storage:volume"
+)
+
+func (source QemuDiskImport) String() string {
+	if source.Path != "" {
+		return source.Path
+	}
+	return source.Volume
+}
+
+func (source QemuDiskImport) Validate() error {
+	if source.Volume == "" && source.Path == "" {
+		return errors.New(Error_QemuDiskImport_Empty)
+	}
+	if source.Volume != "" && source.Path != "" {
+		return errors.New(Error_QemuDiskImport_MutuallyExclusive)
+	}
+	if strings.Contains(source.String(), ",") {
+		return errors.New(Error_QemuDiskImport_IllegalCharacter)
+	}
+	if source.Path != "" {
+		if !strings.HasPrefix(source.Path, "/") {
+			return errors.New(Error_QemuDiskImport_Path)
+		}
+		return nil
+	}
+	storage, volume, _ := strings.Cut(source.Volume, ":")
+	if storage == "" || volume == "" {
+		return errors.New(Error_QemuDiskImport_Volume)
+	}
+	content, file, isFile := strings.Cut(volume, "/")
+	if !isFile {
+		// storage:vm-100-disk-0
+		return nil
+	}
+	if content == string(contentType_Import_ApiValue) {
+		// storage:import/debian-12.qcow2
+		return qemuDiskImportFormat(file)
+	}
+	if _, err := strconv.Atoi(content); err != nil {
+		if strings.HasSuffix(file, "."+string(QemuDiskFormat_Vmdk)) {
+			// esxi storages have no content directory, storage:ha-datacenter/datastore1/guest/guest.vmdk
+			return nil
+		}
+		// only storage:100/vm-100-disk-0.qcow2 is left for content type images
+		return errors.New(Error_QemuDiskImport_ContentType)
+	}
+	return nil
+}
+
+// Returns an error when the file extension is not a disk format that can be imported.
+func qemuDiskImportFormat(file string) error {
+	if index := strings.LastIndex(file, "."); index != -1 {
+		switch QemuDiskFormat(file[index+1:]) {
+		case QemuDiskFormat_Qcow2, QemuDiskFormat_Raw, QemuDiskFormat_Vmdk:
+			return nil
+		}
+	}
+	return errors.New(Error_QemuDiskImport_Format)
+}
+
 type qemuDiskMark struct {
 	Format  QemuDiskFormat
 	Id      QemuDiskId
@@ -800,6 +889,13 @@ func (disk *qemuDiskMark) markChanges(currentDisk *qemuDiskMark, id QemuDiskId,
 		return
 	}
 	// Disk
+	if disk.Size < currentDisk.Size {
+		changes.Shrink = append(changes.Shrink, QemuDiskShrink{
+			Id:                       id,
+			CurrentSizeInKibibytes:   currentDisk.Size,
+			RequestedSizeInKibibytes: disk.Size,
+		})
+	}
 	if disk.Size >= currentDisk.Size {
 		// Update
 		if disk.Size > currentDisk.Size {
@@ -887,6 +983,68 @@ func (disk qemuDiskResize) resize(vmr *VmRef, client *Client) (exitStatus string
 	return client.PutWithTask(map[string]interface{}{"disk": disk.Id, "size": strconv.FormatInt(int64(disk.SizeInKibibytes), 10) + "K"}, fmt.Sprintf("/nodes/%s/%s/%d/resize", vmr.node, vmr.vmType, vmr.vmId))
 }
 
+// A disk that will be grown, either an existing disk or a newly created disk that does not have its size in gibibytes.
+type QemuDiskResizePlan struct {
+	Id                     QemuDiskId   `json:"id"`
+	CurrentSizeInKibibytes QemuDiskSize `json:"current_size"` // 0 for newly created disks
+	NewSizeInKibibytes     QemuDiskSize `json:"new_size"`
+	DeltaInKibibytes       QemuDiskSize `json:"delta"`
+}
+
+// Returns all resize operations that would be executed when updating the disks from current to new.
+// Returns a *QemuDiskShrinkError when any disk would have to be shrunk, as disks can only be grown.
+func PlanDiskResizes(current, new *QemuStorages) ([]QemuDiskResizePlan, error) {
+	if new == nil {
+		return nil, nil
+	}
+	plan := make([]QemuDiskResizePlan, 0)
+	if current != nil {
+		changes := new.markDiskChanges(*current)
+		if len(changes.Shrink) > 0 {
+			return nil, &QemuDiskShrinkError{Disks: changes.Shrink}
+		}
+		currentSizes := current.diskSizes()
+		for _, e := range changes.Resize {
+			plan = append(plan, QemuDiskResizePlan{
+				Id:                     e.Id,
+				CurrentSizeInKibibytes: currentSizes[e.Id],
+				NewSizeInKibibytes:     e.SizeInKibibytes,
+				DeltaInKibibytes:       e.SizeInKibibytes - currentSizes[e.Id],
+			})
+		}
+	}
+	for _, e := range new.selectInitialResize(current) {
+		plan = append(plan, QemuDiskResizePlan{
+			Id:                 e.Id,
+			NewSizeInKibibytes: e.SizeInKibibytes,
+			DeltaInKibibytes:   e.SizeInKibibytes,
+		})
+	}
+	return plan, nil
+}
+
+// A disk that would have to be shrunk, which is not supported.
+type QemuDiskShrink struct {
+	Id                       QemuDiskId   `json:"id"`
+	CurrentSizeInKibibytes   QemuDiskSize `json:"current_size"`
+	RequestedSizeInKibibytes QemuDiskSize `json:"requested_size"`
+}
+
+const QemuDiskShrinkError_Error string = "disks can not be shrunk: "
+
+// Returned when one or more disks would have to be shrunk.
+type QemuDiskShrinkError struct {
+	Disks []QemuDiskShrink
+}
+
+func (err *QemuDiskShrinkError) Error() string {
+	disks := make([]string, len(err.Disks))
+	for i, e := range err.Disks {
+		disks[i] = string(e.Id) + " from " + strconv.FormatUint(uint64(e.CurrentSizeInKibibytes), 10) + "K to " + strconv.FormatUint(uint64(e.RequestedSizeInKibibytes), 10) + "K"
+	}
+	return QemuDiskShrinkError_Error + strings.Join(disks, ", ")
+}
+
 type qemuDiskMove struct {
 	Format  *QemuDiskFormat
 	Id      QemuDiskId
@@ -1093,6 +1251,40 @@ func (QemuStorages) mapToStruct(params map[string]interface{}, linkedVmId *uint)
 	return nil
 }
 
+// Returns the size of every disk, keyed by the id of the disk.
+func (storages QemuStorages) diskSizes() map[QemuDiskId]QemuDiskSize {
+	sizes := make(map[QemuDiskId]QemuDiskSize)
+	if storages.Ide != nil {
+		for i, e := range storages.Ide.mapToIntMap() {
+			if e != nil && e.Disk != nil {
+				sizes[QemuDiskId("ide"+strconv.Itoa(int(i)))] = e.Disk.SizeInKibibytes
+			}
+		}
+	}
+	if storages.Sata != nil {
+		for i, e := range storages.Sata.mapToIntMap() {
+			if e != nil && e.Disk != nil {
+				sizes[QemuDiskId("sata"+strconv.Itoa(int(i)))] = e.Disk.SizeInKibibytes
+			}
+		}
+	}
+	if storages.Scsi != nil {
+		for i, e := range storages.Scsi.mapToIntMap() {
+			if e != nil && e.Disk != nil {
+				sizes[QemuDiskId("scsi"+strconv.Itoa(int(i)))] = e.Disk.SizeInKibibytes
+			}
+		}
+	}
+	if storages.VirtIO != nil {
+		for i, e := range storages.VirtIO.mapToIntMap() {
+			if e != nil && e.Disk != nil {
+				sizes[QemuDiskId("virtio"+strconv.Itoa(int(i)))] = e.Disk.SizeInKibibytes
+			}
+		}
+	}
+	return sizes
+}
+
 // mark disk that need to be moved or resized
 func (storages QemuStorages) markDiskChanges(currentStorages QemuStorages) *qemuUpdateChanges {
 	changes := &qemuUpdateChanges{}
@@ -1111,7 +1303,7 @@ func (storages QemuStorages) markDiskChanges(currentStorages QemuStorages) *qemu
 	return changes
 }
 
-// Select all new disks that do not have their size in gibigytes for resizing to the specified size
+// Select all new disks that do not have their size in gibigytes, or were imported, for resizing to the specified size
 func (newStorages QemuStorages) selectInitialResize(currentStorages *QemuStorages) (resize []qemuDiskResize) {
 	if currentStorages == nil {
 		if newStorages.Ide != nil {
@@ -1190,6 +1382,7 @@ func (storages QemuStorages) Validate() (err error) {
 type qemuUpdateChanges struct {
 	Move   []qemuDiskMove
 	Resize []qemuDiskResize
+	Shrink []QemuDiskShrink
 }
 
 type QemuWorldWideName string
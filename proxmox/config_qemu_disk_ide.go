@@ -13,8 +13,9 @@ type QemuIdeDisk struct {
 	Discard         bool              `json:"discard"`
 	EmulateSSD      bool              `json:"emulatessd"`
 	Format          QemuDiskFormat    `json:"format"`
-	Id              uint              `json:"id"`     //Id is only returned and setting it has no effect
-	LinkedDiskId    *uint             `json:"linked"` //LinkedClone is only returned and setting it has no effect
+	Id              uint              `json:"id"`                    //Id is only returned and setting it has no effect
+	ImportFrom      *QemuDiskImport   `json:"import_from,omitempty"` //ImportFrom is only used when creating the disk
+	LinkedDiskId    *uint             `json:"linked"`                //LinkedClone is only returned and setting it has no effect
 	Replicate       bool              `json:"replicate"`
 	Serial          QemuDiskSerial    `json:"serial,omitempty"`
 	SizeInKibibytes QemuDiskSize      `json:"size"`
@@ -35,6 +36,7 @@ func (disk *QemuIdeDisk) convertDataStructure() *qemuDisk {
 		fileSyntax:      disk.syntax,
 		Format:          disk.Format,
 		Id:              disk.Id,
+		ImportFrom:      disk.ImportFrom,
 		LinkedDiskId:    disk.LinkedDiskId,
 		Replicate:       disk.Replicate,
 		Serial:          disk.Serial,
@@ -88,6 +90,16 @@ func (disks QemuIdeDisks) mapToIntMap() map[uint8]*QemuIdeStorage {
 	}
 }
 
+// Same as mapToIntMap, but the disks can be replaced through the returned pointers.
+func (disks *QemuIdeDisks) mapToIntMapPointer() map[uint8]**QemuIdeStorage {
+	return map[uint8]**QemuIdeStorage{
+		0: &disks.Disk_0,
+		1: &disks.Disk_1,
+		2: &disks.Disk_2,
+		3: &disks.Disk_3,
+	}
+}
+
 func (QemuIdeDisks) mapToStruct(params map[string]interface{}, linkedVmId *uint) *QemuIdeDisks {
 	disks := QemuIdeDisks{}
 	var structPopulated bool
@@ -133,7 +145,7 @@ func (disks QemuIdeDisks) selectInitialResize(currentDisks *QemuIdeDisks) (resiz
 	diskMap := disks.mapToIntMap()
 	currentDiskMap := tmpCurrentDisks.mapToIntMap()
 	for i := range diskMap {
-		if diskMap[i] != nil && diskMap[i].Disk != nil && diskMap[i].Disk.SizeInKibibytes%gibibyte != 0 && (currentDiskMap[i] == nil || currentDiskMap[i].Disk == nil || diskMap[i].Disk.SizeInKibibytes < currentDiskMap[i].Disk.SizeInKibibytes) {
+		if diskMap[i] != nil && diskMap[i].Disk != nil && (diskMap[i].Disk.SizeInKibibytes%gibibyte != 0 || (diskMap[i].Disk.ImportFrom != nil && diskMap[i].Disk.SizeInKibibytes != 0)) && (currentDiskMap[i] == nil || currentDiskMap[i].Disk == nil || diskMap[i].Disk.SizeInKibibytes < currentDiskMap[i].Disk.SizeInKibibytes) {
 			aaa := diskMap[i].Disk.SizeInKibibytes % gibibyte
 			_ = aaa
 			resize = append(resize, qemuDiskResize{
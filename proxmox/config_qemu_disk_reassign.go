@@ -0,0 +1,121 @@
+package proxmox
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+)
+
+const (
+	ReassignDisk_Error_DiskNotFound    string = "source disk does not exist: "
+	ReassignDisk_Error_GuestType       string = "disks can only be reassigned between guests of the same type"
+	ReassignDisk_Error_LxcMountPoint   string = "invalid mount point, should be mp[n] or unused[n] for the source and mp[n] for the target"
+	ReassignDisk_Error_NodeMismatch    string = "source and target guest should be on the same node"
+	ReassignDisk_Error_SameGuest       string = "source and target guest may not be the same"
+	ReassignDisk_Error_SlotUsed        string = "target disk slot is already in use: "
+	ReassignDisk_Error_UnsupportedType string = "unsupported guest type: "
+)
+
+var (
+	rxLxcMountPoint = regexp.MustCompile(`^mp(0|[1-9][0-9]?|1[0-9]{2}|2[0-4][0-9]|25[0-5])$`)     // mp0 through mp255
+	rxUnusedDiskId  = regexp.MustCompile(`^unused(0|[1-9][0-9]?|1[0-9]{2}|2[0-4][0-9]|25[0-5])$`) // unused0 through unused255
+)
+
+// Reassigns the disk of the source guest to the target guest as targetDisk.
+// Both guests have to be of the same type and on the same node, and the slot of targetDisk may not be in use.
+// For lxc guests disk and targetDisk are mount points, e.g. mp0.
+// The source disk may also be an unused disk, e.g. unused0.
+// For qemu guests the refreshed disks of the source and target guest are returned, for lxc guests these are nil.
+func ReassignDisk(c *Client, source *VmRef, disk QemuDiskId, target *VmRef, targetDisk QemuDiskId) (*QemuStorages, *QemuStorages, error) {
+	if c == nil {
+		return nil, nil, errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(source); err != nil {
+		return nil, nil, err
+	}
+	if err := c.CheckVmRef(target); err != nil {
+		return nil, nil, err
+	}
+	if err := validateReassignDisk(source, disk, target, targetDisk); err != nil {
+		return nil, nil, err
+	}
+	sourceConfig, err := c.GetVmConfig(source)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, isSet := sourceConfig[string(disk)]; !isSet {
+		return nil, nil, errors.New(ReassignDisk_Error_DiskNotFound + string(disk))
+	}
+	targetPending, err := pendingGuestConfigFromApi(target, c)
+	if err != nil {
+		return nil, nil, err
+	}
+	if diskSlotUsed(targetPending, targetDisk) {
+		return nil, nil, errors.New(ReassignDisk_Error_SlotUsed + string(targetDisk))
+	}
+	url := "/nodes/" + source.node + "/" + source.vmType + "/" + strconv.Itoa(source.vmId)
+	if GuestType(source.vmType) == GuestLXC {
+		_, err = c.PostWithTask(map[string]interface{}{
+			"volume":        string(disk),
+			"target-vmid":   target.vmId,
+			"target-volume": string(targetDisk),
+		}, url+"/move_volume")
+		return nil, nil, err
+	}
+	if _, err = c.PostWithTask(map[string]interface{}{
+		"disk":        string(disk),
+		"target-vmid": target.vmId,
+		"target-disk": string(targetDisk),
+	}, url+"/move_disk"); err != nil {
+		return nil, nil, err
+	}
+	sourceDisks, err := readQemuStorages(c, source)
+	if err != nil {
+		return nil, nil, err
+	}
+	targetDisks, err := readQemuStorages(c, target)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sourceDisks, targetDisks, nil
+}
+
+func readQemuStorages(c *Client, vmr *VmRef) (*QemuStorages, error) {
+	params, err := c.GetVmConfig(vmr)
+	if err != nil {
+		return nil, err
+	}
+	linkedVmId := uint(0)
+	return QemuStorages{}.mapToStruct(params, &linkedVmId), nil
+}
+
+// Validates the reassignment without contacting the api, the guest type and node of both guests have to be known.
+func validateReassignDisk(source *VmRef, disk QemuDiskId, target *VmRef, targetDisk QemuDiskId) error {
+	if source.vmId == target.vmId {
+		return errors.New(ReassignDisk_Error_SameGuest)
+	}
+	if source.vmType != target.vmType {
+		return errors.New(ReassignDisk_Error_GuestType)
+	}
+	if source.node != target.node {
+		return errors.New(ReassignDisk_Error_NodeMismatch)
+	}
+	switch GuestType(source.vmType) {
+	case GuestQemu:
+		if !rxUnusedDiskId.MatchString(string(disk)) {
+			if err := disk.Validate(); err != nil {
+				return err
+			}
+		}
+		return targetDisk.Validate()
+	case GuestLXC:
+		if !rxLxcMountPoint.MatchString(string(disk)) && !rxUnusedDiskId.MatchString(string(disk)) {
+			return errors.New(ReassignDisk_Error_LxcMountPoint)
+		}
+		if !rxLxcMountPoint.MatchString(string(targetDisk)) {
+			return errors.New(ReassignDisk_Error_LxcMountPoint)
+		}
+		return nil
+	}
+	return errors.New(ReassignDisk_Error_UnsupportedType + source.vmType)
+}
@@ -0,0 +1,80 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateReassignDisk(t *testing.T) {
+	qemu := func(id int, node string) *VmRef { return &VmRef{vmId: id, node: node, vmType: string(GuestQemu)} }
+	lxc := func(id int, node string) *VmRef { return &VmRef{vmId: id, node: node, vmType: string(GuestLXC)} }
+	type testInput struct {
+		source     *VmRef
+		disk       QemuDiskId
+		target     *VmRef
+		targetDisk QemuDiskId
+	}
+	tests := []struct {
+		name   string
+		input  testInput
+		output error
+	}{
+		{name: `Valid qemu`,
+			input: testInput{source: qemu(100, "pve"), disk: "scsi1", target: qemu(101, "pve"), targetDisk: "virtio3"}},
+		{name: `Valid qemu unused`,
+			input: testInput{source: qemu(100, "pve"), disk: "unused0", target: qemu(101, "pve"), targetDisk: "scsi2"}},
+		{name: `Valid qemu last unused`,
+			input: testInput{source: qemu(100, "pve"), disk: "unused255", target: qemu(101, "pve"), targetDisk: "scsi2"}},
+		{name: `Valid lxc`,
+			input: testInput{source: lxc(100, "pve"), disk: "mp0", target: lxc(101, "pve"), targetDisk: "mp5"}},
+		{name: `Valid lxc last mount point`,
+			input: testInput{source: lxc(100, "pve"), disk: "mp199", target: lxc(101, "pve"), targetDisk: "mp255"}},
+		{name: `Valid lxc unused`,
+			input: testInput{source: lxc(100, "pve"), disk: "unused1", target: lxc(101, "pve"), targetDisk: "mp0"}},
+		{name: `Valid lxc last unused`,
+			input: testInput{source: lxc(100, "pve"), disk: "unused255", target: lxc(101, "pve"), targetDisk: "mp0"}},
+		{name: `Invalid same guest`,
+			input:  testInput{source: qemu(100, "pve"), disk: "scsi1", target: qemu(100, "pve"), targetDisk: "scsi2"},
+			output: errors.New(ReassignDisk_Error_SameGuest)},
+		{name: `Invalid guest type`,
+			input:  testInput{source: qemu(100, "pve"), disk: "scsi1", target: lxc(101, "pve"), targetDisk: "mp0"},
+			output: errors.New(ReassignDisk_Error_GuestType)},
+		{name: `Invalid node`,
+			input:  testInput{source: qemu(100, "pve1"), disk: "scsi1", target: qemu(101, "pve2"), targetDisk: "scsi1"},
+			output: errors.New(ReassignDisk_Error_NodeMismatch)},
+		{name: `Invalid qemu disk`,
+			input:  testInput{source: qemu(100, "pve"), disk: "scsi31", target: qemu(101, "pve"), targetDisk: "scsi1"},
+			output: errors.New(ERROR_QemuDiskId_Invalid)},
+		{name: `Invalid qemu unused`,
+			input:  testInput{source: qemu(100, "pve"), disk: "unused256", target: qemu(101, "pve"), targetDisk: "scsi1"},
+			output: errors.New(ERROR_QemuDiskId_Invalid)},
+		{name: `Invalid qemu target disk`,
+			input:  testInput{source: qemu(100, "pve"), disk: "scsi1", target: qemu(101, "pve"), targetDisk: "unused0"},
+			output: errors.New(ERROR_QemuDiskId_Invalid)},
+		{name: `Invalid lxc rootfs`,
+			input:  testInput{source: lxc(100, "pve"), disk: "rootfs", target: lxc(101, "pve"), targetDisk: "mp0"},
+			output: errors.New(ReassignDisk_Error_LxcMountPoint)},
+		{name: `Invalid lxc mount point`,
+			input:  testInput{source: lxc(100, "pve"), disk: "mp256", target: lxc(101, "pve"), targetDisk: "mp0"},
+			output: errors.New(ReassignDisk_Error_LxcMountPoint)},
+		{name: `Invalid lxc unused`,
+			input:  testInput{source: lxc(100, "pve"), disk: "unused256", target: lxc(101, "pve"), targetDisk: "mp0"},
+			output: errors.New(ReassignDisk_Error_LxcMountPoint)},
+		{name: `Invalid lxc target mount point`,
+			input:  testInput{source: lxc(100, "pve"), disk: "mp0", target: lxc(101, "pve"), targetDisk: "mp999"},
+			output: errors.New(ReassignDisk_Error_LxcMountPoint)},
+		{name: `Invalid lxc target disk`,
+			input:  testInput{source: lxc(100, "pve"), disk: "mp0", target: lxc(101, "pve"), targetDisk: "unused0"},
+			output: errors.New(ReassignDisk_Error_LxcMountPoint)},
+		{name: `Invalid guest type unknown`,
+			input:  testInput{source: &VmRef{vmId: 100, node: "pve", vmType: "openvz"}, disk: "mp0", target: &VmRef{vmId: 101, node: "pve", vmType: "openvz"}, targetDisk: "mp1"},
+			output: errors.New(ReassignDisk_Error_UnsupportedType + "openvz")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, validateReassignDisk(test.input.source, test.input.disk, test.input.target, test.input.targetDisk))
+		})
+	}
+}
@@ -13,7 +13,8 @@ type QemuScsiDisk struct {
 	Discard         bool              `json:"discard"`
 	EmulateSSD      bool              `json:"emulatessd"`
 	Format          QemuDiskFormat    `json:"format"`
-	Id              uint              `json:"id"` //Id is only returned and setting it has no effect
+	Id              uint              `json:"id"`                    //Id is only returned and setting it has no effect
+	ImportFrom      *QemuDiskImport   `json:"import_from,omitempty"` //ImportFrom is only used when creating the disk
 	IOThread        bool              `json:"iothread"`
 	LinkedDiskId    *uint             `json:"linked"` //LinkedCloneId is only returned and setting it has no effect
 	ReadOnly        bool              `json:"readonly"`
@@ -36,6 +37,7 @@ func (disk *QemuScsiDisk) convertDataStructure() *qemuDisk {
 		EmulateSSD:      disk.EmulateSSD,
 		Format:          disk.Format,
 		Id:              disk.Id,
+		ImportFrom:      disk.ImportFrom,
 		IOThread:        disk.IOThread,
 		LinkedDiskId:    disk.LinkedDiskId,
 		ReadOnly:        disk.ReadOnly,
@@ -146,6 +148,43 @@ func (disks QemuScsiDisks) mapToIntMap() map[uint8]*QemuScsiStorage {
 	}
 }
 
+// Same as mapToIntMap, but the disks can be replaced through the returned pointers.
+func (disks *QemuScsiDisks) mapToIntMapPointer() map[uint8]**QemuScsiStorage {
+	return map[uint8]**QemuScsiStorage{
+		0:  &disks.Disk_0,
+		1:  &disks.Disk_1,
+		2:  &disks.Disk_2,
+		3:  &disks.Disk_3,
+		4:  &disks.Disk_4,
+		5:  &disks.Disk_5,
+		6:  &disks.Disk_6,
+		7:  &disks.Disk_7,
+		8:  &disks.Disk_8,
+		9:  &disks.Disk_9,
+		10: &disks.Disk_10,
+		11: &disks.Disk_11,
+		12: &disks.Disk_12,
+		13: &disks.Disk_13,
+		14: &disks.Disk_14,
+		15: &disks.Disk_15,
+		16: &disks.Disk_16,
+		17: &disks.Disk_17,
+		18: &disks.Disk_18,
+		19: &disks.Disk_19,
+		20: &disks.Disk_20,
+		21: &disks.Disk_21,
+		22: &disks.Disk_22,
+		23: &disks.Disk_23,
+		24: &disks.Disk_24,
+		25: &disks.Disk_25,
+		26: &disks.Disk_26,
+		27: &disks.Disk_27,
+		28: &disks.Disk_28,
+		29: &disks.Disk_29,
+		30: &disks.Disk_30,
+	}
+}
+
 func (QemuScsiDisks) mapToStruct(params map[string]interface{}, linkedVmId *uint) *QemuScsiDisks {
 	disks := QemuScsiDisks{}
 	var structPopulated bool
@@ -299,7 +338,7 @@ func (disks QemuScsiDisks) selectInitialResize(currentDisks *QemuScsiDisks) (res
 	diskMap := disks.mapToIntMap()
 	currentDiskMap := tmpCurrentDisks.mapToIntMap()
 	for i := range diskMap {
-		if diskMap[i] != nil && diskMap[i].Disk != nil && diskMap[i].Disk.SizeInKibibytes%gibibyte != 0 && (currentDiskMap[i] == nil || currentDiskMap[i].Disk == nil || diskMap[i].Disk.SizeInKibibytes < currentDiskMap[i].Disk.SizeInKibibytes) {
+		if diskMap[i] != nil && diskMap[i].Disk != nil && (diskMap[i].Disk.SizeInKibibytes%gibibyte != 0 || (diskMap[i].Disk.ImportFrom != nil && diskMap[i].Disk.SizeInKibibytes != 0)) && (currentDiskMap[i] == nil || currentDiskMap[i].Disk == nil || diskMap[i].Disk.SizeInKibibytes < currentDiskMap[i].Disk.SizeInKibibytes) {
 			resize = append(resize, qemuDiskResize{
 				Id:              QemuDiskId("scsi" + strconv.Itoa(int(i))),
 				SizeInKibibytes: diskMap[i].Disk.SizeInKibibytes,
@@ -707,6 +707,39 @@ func Test_QemuDiskId_Validate(t *testing.T) {
 	}
 }
 
+func Test_QemuDiskImport_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  QemuDiskImport
+		output error
+	}{
+		// Valid
+		{name: "Valid Path", input: QemuDiskImport{Path: "/mnt/images/debian-12.img"}},
+		{name: "Valid Volume import", input: QemuDiskImport{Volume: "local:import/debian-12.qcow2"}},
+		{name: "Valid Volume import raw", input: QemuDiskImport{Volume: "local:import/debian-12.raw"}},
+		{name: "Valid Volume import vmdk", input: QemuDiskImport{Volume: "local:import/debian-12.vmdk"}},
+		{name: "Valid Volume images file", input: QemuDiskImport{Volume: "local:100/vm-100-disk-0.qcow2"}},
+		{name: "Valid Volume images volume", input: QemuDiskImport{Volume: "local-lvm:vm-100-disk-0"}},
+		{name: "Valid Volume esxi", input: QemuDiskImport{Volume: "esxi:ha-datacenter/datastore1/guest/guest.vmdk"}},
+		// Invalid
+		{name: "Invalid Empty", output: errors.New(Error_QemuDiskImport_Empty)},
+		{name: "Invalid MutuallyExclusive", input: QemuDiskImport{Volume: "local:import/debian-12.qcow2", Path: "/mnt/debian-12.qcow2"}, output: errors.New(Error_QemuDiskImport_MutuallyExclusive)},
+		{name: "Invalid IllegalCharacter", input: QemuDiskImport{Path: "/mnt/debian,12.qcow2"}, output: errors.New(Error_QemuDiskImport_IllegalCharacter)},
+		{name: "Invalid Path relative", input: QemuDiskImport{Path: "mnt/debian-12.qcow2"}, output: errors.New(Error_QemuDiskImport_Path)},
+		{name: "Invalid Volume no storage", input: QemuDiskImport{Volume: ":import/debian-12.qcow2"}, output: errors.New(Error_QemuDiskImport_Volume)},
+		{name: "Invalid Volume no volume", input: QemuDiskImport{Volume: "local"}, output: errors.New(Error_QemuDiskImport_Volume)},
+		{name: "Invalid Volume iso", input: QemuDiskImport{Volume: "local:iso/debian-12.iso"}, output: errors.New(Error_QemuDiskImport_ContentType)},
+		{name: "Invalid Volume esxi not vmdk", input: QemuDiskImport{Volume: "esxi:ha-datacenter/datastore1/guest/guest.nvram"}, output: errors.New(Error_QemuDiskImport_ContentType)},
+		{name: "Invalid Volume import format", input: QemuDiskImport{Volume: "local:import/debian-12.ova"}, output: errors.New(Error_QemuDiskImport_Format)},
+		{name: "Invalid Volume import no extension", input: QemuDiskImport{Volume: "local:import/debian-12"}, output: errors.New(Error_QemuDiskImport_Format)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
+
 func Test_QemuDiskSerial_Validate(t *testing.T) {
 	testRunes := struct {
 		legal   []string
@@ -1016,7 +1049,12 @@ func Test_QemuStorages_markDiskChanges(t *testing.T) {
 				Scsi:   &QemuScsiDisks{Disk_6: &QemuScsiStorage{Disk: &QemuScsiDisk{Format: QemuDiskFormat_Raw, SizeInKibibytes: 32, Storage: "Test"}}},
 				VirtIO: &QemuVirtIODisks{Disk_7: &QemuVirtIOStorage{Disk: &QemuVirtIODisk{Format: QemuDiskFormat_Raw, SizeInKibibytes: 32, Storage: "Test"}}},
 			},
-			output: &qemuUpdateChanges{},
+			output: &qemuUpdateChanges{Shrink: []QemuDiskShrink{
+				{Id: "ide0", CurrentSizeInKibibytes: 32, RequestedSizeInKibibytes: 1},
+				{Id: "sata5", CurrentSizeInKibibytes: 32, RequestedSizeInKibibytes: 10},
+				{Id: "scsi6", CurrentSizeInKibibytes: 32, RequestedSizeInKibibytes: 20},
+				{Id: "virtio7", CurrentSizeInKibibytes: 32, RequestedSizeInKibibytes: 31},
+			}},
 		},
 		{name: "Disk.Storage CHANGE",
 			storages: QemuStorages{
@@ -1049,6 +1087,62 @@ func Test_QemuStorages_markDiskChanges(t *testing.T) {
 	}
 }
 
+func Test_PlanDiskResizes(t *testing.T) {
+	type testInput struct {
+		current *QemuStorages
+		new     *QemuStorages
+	}
+	tests := []struct {
+		name   string
+		input  testInput
+		output []QemuDiskResizePlan
+		err    error
+	}{
+		{name: `nil`},
+		{name: `Create`,
+			input: testInput{new: &QemuStorages{
+				Ide:  &QemuIdeDisks{Disk_0: &QemuIdeStorage{Disk: &QemuIdeDisk{SizeInKibibytes: 1048576}}},
+				Scsi: &QemuScsiDisks{Disk_1: &QemuScsiStorage{Disk: &QemuScsiDisk{SizeInKibibytes: 1048577}}},
+			}},
+			output: []QemuDiskResizePlan{
+				{Id: "scsi1", NewSizeInKibibytes: 1048577, DeltaInKibibytes: 1048577}}},
+		{name: `Update`,
+			input: testInput{
+				current: &QemuStorages{
+					Sata:   &QemuSataDisks{Disk_2: &QemuSataStorage{Disk: &QemuSataDisk{Format: QemuDiskFormat_Raw, SizeInKibibytes: 1048576, Storage: "Test"}}},
+					VirtIO: &QemuVirtIODisks{Disk_3: &QemuVirtIOStorage{Disk: &QemuVirtIODisk{Format: QemuDiskFormat_Raw, SizeInKibibytes: 1048576, Storage: "Test"}}},
+				},
+				new: &QemuStorages{
+					Sata:   &QemuSataDisks{Disk_2: &QemuSataStorage{Disk: &QemuSataDisk{Format: QemuDiskFormat_Raw, SizeInKibibytes: 2097152, Storage: "Test"}}},
+					VirtIO: &QemuVirtIODisks{Disk_3: &QemuVirtIOStorage{Disk: &QemuVirtIODisk{Format: QemuDiskFormat_Raw, SizeInKibibytes: 1048576, Storage: "Test"}}},
+				}},
+			output: []QemuDiskResizePlan{
+				{Id: "sata2", CurrentSizeInKibibytes: 1048576, NewSizeInKibibytes: 2097152, DeltaInKibibytes: 1048576}}},
+		{name: `Shrink`,
+			input: testInput{
+				current: &QemuStorages{Scsi: &QemuScsiDisks{Disk_4: &QemuScsiStorage{Disk: &QemuScsiDisk{Format: QemuDiskFormat_Raw, SizeInKibibytes: 2097152, Storage: "Test"}}}},
+				new:     &QemuStorages{Scsi: &QemuScsiDisks{Disk_4: &QemuScsiStorage{Disk: &QemuScsiDisk{Format: QemuDiskFormat_Raw, SizeInKibibytes: 1048576, Storage: "Test"}}}}},
+			err: &QemuDiskShrinkError{Disks: []QemuDiskShrink{
+				{Id: "scsi4", CurrentSizeInKibibytes: 2097152, RequestedSizeInKibibytes: 1048576}}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plan, err := PlanDiskResizes(test.input.current, test.input.new)
+			require.Equal(t, test.err, err)
+			if test.err == nil && test.input.new != nil {
+				require.Equal(t, test.output, plan)
+			}
+		})
+	}
+}
+
+func Test_QemuDiskShrinkError_Error(t *testing.T) {
+	err := &QemuDiskShrinkError{Disks: []QemuDiskShrink{
+		{Id: "scsi0", CurrentSizeInKibibytes: 2097152, RequestedSizeInKibibytes: 1048576},
+		{Id: "virtio1", CurrentSizeInKibibytes: 8192, RequestedSizeInKibibytes: 4097}}}
+	require.Equal(t, QemuDiskShrinkError_Error+"scsi0 from 2097152K to 1048576K, virtio1 from 8192K to 4097K", err.Error())
+}
+
 func Test_QemuStorages_selectInitialResize(t *testing.T) {
 	type testInput struct {
 		currentStorages *QemuStorages
@@ -1218,6 +1312,19 @@ func Test_QemuStorages_selectInitialResize(t *testing.T) {
 				{Id: "virtio7", SizeInKibibytes: 323},
 			},
 		},
+		{name: "Import gibibyte",
+			input: testInput{newStorages: QemuStorages{
+				Ide:    &QemuIdeDisks{Disk_1: &QemuIdeStorage{Disk: &QemuIdeDisk{ImportFrom: &QemuDiskImport{Path: "/tmp/disk.raw"}, SizeInKibibytes: 10485760}}},
+				Sata:   &QemuSataDisks{Disk_2: &QemuSataStorage{Disk: &QemuSataDisk{ImportFrom: &QemuDiskImport{Path: "/tmp/disk.raw"}, SizeInKibibytes: 1048576}}},
+				Scsi:   &QemuScsiDisks{Disk_3: &QemuScsiStorage{Disk: &QemuScsiDisk{ImportFrom: &QemuDiskImport{Path: "/tmp/disk.raw"}}}},
+				VirtIO: &QemuVirtIODisks{Disk_4: &QemuVirtIOStorage{Disk: &QemuVirtIODisk{ImportFrom: &QemuDiskImport{Path: "/tmp/disk.raw"}, SizeInKibibytes: 872415232}}},
+			}},
+			output: []qemuDiskResize{
+				{Id: "ide1", SizeInKibibytes: 10485760},
+				{Id: "sata2", SizeInKibibytes: 1048576},
+				{Id: "virtio4", SizeInKibibytes: 872415232},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
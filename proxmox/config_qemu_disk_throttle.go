@@ -0,0 +1,181 @@
+package proxmox
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// A named, reusable set of disk throttling settings, e.g. "gold" or "bronze".
+type QemuDiskThrottleProfile struct {
+	Name      string            `json:"name"`
+	Bandwidth QemuDiskBandwidth `json:"bandwidth"`
+}
+
+const QemuDiskThrottleProfile_Error_NameEmpty string = "throttle profile name may not be empty"
+
+func (profile QemuDiskThrottleProfile) Validate() error {
+	if profile.Name == "" {
+		return errors.New(QemuDiskThrottleProfile_Error_NameEmpty)
+	}
+	return profile.Bandwidth.Validate()
+}
+
+// Selects the disks a throttle profile is applied to.
+// When all fields are empty every disk and passthrough disk matches.
+type QemuDiskThrottleFilter struct {
+	Ids     []QemuDiskId `json:"ids,omitempty"`     // only match disks with one of these ids
+	Storage string       `json:"storage,omitempty"` // only match disks on this storage, passthrough disks never match
+}
+
+func (filter QemuDiskThrottleFilter) match(id QemuDiskId, storage string, passthrough bool) bool {
+	if len(filter.Ids) > 0 {
+		var found bool
+		for _, e := range filter.Ids {
+			if e == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if filter.Storage != "" && (passthrough || filter.Storage != storage) {
+		return false
+	}
+	return true
+}
+
+func (filter QemuDiskThrottleFilter) Validate() error {
+	for _, e := range filter.Ids {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sets the bandwidth of the profile on all disks matching the filter.
+// Returns the ids of the disks of which the bandwidth changed.
+func (storages *QemuStorages) ApplyThrottleProfile(profile QemuDiskThrottleProfile, filter QemuDiskThrottleFilter) []QemuDiskId {
+	if storages == nil {
+		return nil
+	}
+	changed := make([]QemuDiskId, 0)
+	apply := func(id QemuDiskId, storage string, passthrough bool, bandwidth *QemuDiskBandwidth) {
+		if !filter.match(id, storage, passthrough) || *bandwidth == profile.Bandwidth {
+			return
+		}
+		*bandwidth = profile.Bandwidth
+		changed = append(changed, id)
+	}
+	if storages.Ide != nil {
+		for i, e := range storages.Ide.mapToIntMap() {
+			id := QemuDiskId("ide" + strconv.Itoa(int(i)))
+			if e != nil && e.Disk != nil {
+				apply(id, e.Disk.Storage, false, &e.Disk.Bandwidth)
+			} else if e != nil && e.Passthrough != nil {
+				apply(id, "", true, &e.Passthrough.Bandwidth)
+			}
+		}
+	}
+	if storages.Sata != nil {
+		for i, e := range storages.Sata.mapToIntMap() {
+			id := QemuDiskId("sata" + strconv.Itoa(int(i)))
+			if e != nil && e.Disk != nil {
+				apply(id, e.Disk.Storage, false, &e.Disk.Bandwidth)
+			} else if e != nil && e.Passthrough != nil {
+				apply(id, "", true, &e.Passthrough.Bandwidth)
+			}
+		}
+	}
+	if storages.Scsi != nil {
+		for i, e := range storages.Scsi.mapToIntMap() {
+			id := QemuDiskId("scsi" + strconv.Itoa(int(i)))
+			if e != nil && e.Disk != nil {
+				apply(id, e.Disk.Storage, false, &e.Disk.Bandwidth)
+			} else if e != nil && e.Passthrough != nil {
+				apply(id, "", true, &e.Passthrough.Bandwidth)
+			}
+		}
+	}
+	if storages.VirtIO != nil {
+		for i, e := range storages.VirtIO.mapToIntMap() {
+			id := QemuDiskId("virtio" + strconv.Itoa(int(i)))
+			if e != nil && e.Disk != nil {
+				apply(id, e.Disk.Storage, false, &e.Disk.Bandwidth)
+			} else if e != nil && e.Passthrough != nil {
+				apply(id, "", true, &e.Passthrough.Bandwidth)
+			}
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i] < changed[j] })
+	return changed
+}
+
+// Outcome of applying a throttle profile to a guest.
+type QemuDiskThrottleResult struct {
+	GuestID        uint         `json:"guest_id"`
+	Disks          []QemuDiskId `json:"disks,omitempty"` // disks of which the bandwidth changed
+	RebootRequired bool         `json:"reboot_required"` // the change only takes effect after the guest is rebooted
+}
+
+// Applies the throttle profile to the disks matching the filter of every guest.
+// Guests are not rebooted, the result reports which guests need a reboot for the change to take effect.
+// Processing stops at the first error, the results of the guests processed so far are returned.
+func ApplyThrottleProfile(c *Client, profile QemuDiskThrottleProfile, filter QemuDiskThrottleFilter, guests []*VmRef) ([]QemuDiskThrottleResult, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	results := make([]QemuDiskThrottleResult, 0, len(guests))
+	for _, vmr := range guests {
+		if err := c.CheckVmRef(vmr); err != nil {
+			return results, err
+		}
+		config, err := NewConfigQemuFromApi(vmr, c)
+		if err != nil {
+			return results, err
+		}
+		result := QemuDiskThrottleResult{GuestID: uint(vmr.vmId)}
+		result.Disks = config.Disks.ApplyThrottleProfile(profile, filter)
+		if len(result.Disks) > 0 {
+			if result.RebootRequired, err = config.Update(false, vmr, c); err != nil {
+				return results, err
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Applies the throttle profile to the disks matching the filter of every qemu guest in the pool.
+// See ApplyThrottleProfile for details.
+func (pool PoolName) ApplyThrottleProfile(c *Client, profile QemuDiskThrottleProfile, filter QemuDiskThrottleFilter) ([]QemuDiskThrottleResult, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if err := pool.Validate(); err != nil {
+		return nil, err
+	}
+	guests, err := ListGuests(c)
+	if err != nil {
+		return nil, err
+	}
+	vmrs := make([]*VmRef, 0)
+	for _, e := range guests {
+		if e.Pool == pool && e.Type == GuestQemu && !e.Template {
+			vmr := NewVmRef(int(e.Id))
+			vmr.SetNode(e.Node)
+			vmr.SetVmType(string(GuestQemu))
+			vmrs = append(vmrs, vmr)
+		}
+	}
+	return ApplyThrottleProfile(c, profile, filter, vmrs)
+}
@@ -0,0 +1,95 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_QemuDiskThrottleProfile_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  QemuDiskThrottleProfile
+		output error
+	}{
+		{name: `Valid`,
+			input: QemuDiskThrottleProfile{Name: "gold", Bandwidth: QemuDiskBandwidth{MBps: QemuDiskBandwidthMBps{ReadLimit: QemuDiskBandwidthMBpsLimit{Concurrent: 100}}}}},
+		{name: `Valid empty bandwidth`,
+			input: QemuDiskThrottleProfile{Name: "unlimited"}},
+		{name: `Invalid name`,
+			input:  QemuDiskThrottleProfile{},
+			output: errors.New(QemuDiskThrottleProfile_Error_NameEmpty)},
+		{name: `Invalid bandwidth`,
+			input:  QemuDiskThrottleProfile{Name: "bronze", Bandwidth: QemuDiskBandwidth{MBps: QemuDiskBandwidthMBps{ReadLimit: QemuDiskBandwidthMBpsLimit{Concurrent: 0.5}}}},
+			output: errors.New(Error_QemuDiskBandwidthMBpsLimitConcurrent)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
+
+func Test_QemuDiskThrottleFilter_Validate(t *testing.T) {
+	require.NoError(t, QemuDiskThrottleFilter{}.Validate())
+	require.NoError(t, QemuDiskThrottleFilter{Ids: []QemuDiskId{"ide0", "virtio15"}}.Validate())
+	require.Error(t, QemuDiskThrottleFilter{Ids: []QemuDiskId{"scsi31"}}.Validate())
+}
+
+func Test_QemuStorages_ApplyThrottleProfile(t *testing.T) {
+	gold := QemuDiskThrottleProfile{Name: "gold", Bandwidth: QemuDiskBandwidth{Iops: QemuDiskBandwidthIops{ReadLimit: QemuDiskBandwidthIopsLimit{Concurrent: 1000}}}}
+	storages := func() *QemuStorages {
+		return &QemuStorages{
+			Ide: &QemuIdeDisks{
+				Disk_0: &QemuIdeStorage{CdRom: &QemuCdRom{}},
+				Disk_1: &QemuIdeStorage{Passthrough: &QemuIdePassthrough{File: "/dev/sdb"}}},
+			Sata: &QemuSataDisks{
+				Disk_2: &QemuSataStorage{Disk: &QemuSataDisk{Storage: "local-lvm"}}},
+			Scsi: &QemuScsiDisks{
+				Disk_0: &QemuScsiStorage{Disk: &QemuScsiDisk{Storage: "ceph", Bandwidth: gold.Bandwidth}},
+				Disk_1: &QemuScsiStorage{Disk: &QemuScsiDisk{Storage: "ceph"}}},
+			VirtIO: &QemuVirtIODisks{
+				Disk_3: &QemuVirtIOStorage{Disk: &QemuVirtIODisk{Storage: "local-lvm"}}},
+		}
+	}
+	tests := []struct {
+		name   string
+		input  QemuDiskThrottleFilter
+		output []QemuDiskId
+	}{
+		{name: `All disks`,
+			output: []QemuDiskId{"ide1", "sata2", "scsi1", "virtio3"}},
+		{name: `Storage`,
+			input:  QemuDiskThrottleFilter{Storage: "local-lvm"},
+			output: []QemuDiskId{"sata2", "virtio3"}},
+		{name: `Ids`,
+			input:  QemuDiskThrottleFilter{Ids: []QemuDiskId{"ide0", "ide1", "scsi0", "virtio3"}},
+			output: []QemuDiskId{"ide1", "virtio3"}},
+		{name: `Ids and Storage`,
+			input:  QemuDiskThrottleFilter{Ids: []QemuDiskId{"sata2", "scsi1"}, Storage: "ceph"},
+			output: []QemuDiskId{"scsi1"}},
+		{name: `No match`,
+			input:  QemuDiskThrottleFilter{Storage: "nfs"},
+			output: []QemuDiskId{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			disks := storages()
+			require.Equal(t, test.output, disks.ApplyThrottleProfile(gold, test.input))
+			for _, id := range test.output {
+				switch id {
+				case "ide1":
+					require.Equal(t, gold.Bandwidth, disks.Ide.Disk_1.Passthrough.Bandwidth)
+				case "sata2":
+					require.Equal(t, gold.Bandwidth, disks.Sata.Disk_2.Disk.Bandwidth)
+				case "scsi1":
+					require.Equal(t, gold.Bandwidth, disks.Scsi.Disk_1.Disk.Bandwidth)
+				case "virtio3":
+					require.Equal(t, gold.Bandwidth, disks.VirtIO.Disk_3.Disk.Bandwidth)
+				}
+			}
+		})
+	}
+	require.Nil(t, (*QemuStorages)(nil).ApplyThrottleProfile(gold, QemuDiskThrottleFilter{}))
+}
@@ -0,0 +1,183 @@
+package proxmox
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A volume that belongs to the guest but is not attached to it.
+type QemuUnusedDisk struct {
+	Id              uint           `json:"id"` // the n in unused[n]
+	Storage         string         `json:"storage"`
+	Volume          string         `json:"volume"` // e.g. "100/vm-100-disk-1.qcow2" or "vm-100-disk-1"
+	Format          QemuDiskFormat `json:"format"`
+	SizeInKibibytes QemuDiskSize   `json:"size"`
+}
+
+const (
+	QemuUnusedDisk_Error_NotFound string = "unused disk does not exist: unused"
+	QemuUnusedDisk_Error_SlotUsed string = "disk slot is already in use: "
+)
+
+// Returns the volume id of the unused disk, e.g. "local:100/vm-100-disk-1.qcow2".
+func (disk QemuUnusedDisk) VolumeId() string {
+	return disk.Storage + ":" + disk.Volume
+}
+
+// Maps the unused[n] settings of the guest config, the size is not part of the guest config.
+func (QemuUnusedDisk) mapToSDK(params map[string]interface{}) []QemuUnusedDisk {
+	disks := make([]QemuUnusedDisk, 0)
+	for key, value := range params {
+		if !strings.HasPrefix(key, "unused") {
+			continue
+		}
+		id, err := strconv.ParseUint(key[6:], 10, 0)
+		if err != nil {
+			continue
+		}
+		rawVolume, ok := value.(string)
+		if !ok {
+			continue
+		}
+		disk := QemuUnusedDisk{Id: uint(id), Format: QemuDiskFormat_Raw}
+		disk.Storage, disk.Volume, _ = strings.Cut(rawVolume, ":")
+		if index := strings.LastIndex(disk.Volume, "."); index != -1 {
+			disk.Format = QemuDiskFormat(disk.Volume[index+1:])
+		}
+		disks = append(disks, disk)
+	}
+	sort.Slice(disks, func(i, j int) bool { return disks[i].Id < disks[j].Id })
+	return disks
+}
+
+// Reads the size and format of the volume from the storage.
+func (disk *QemuUnusedDisk) readVolumeInfo(c *Client, node string) error {
+	params, err := c.GetItemConfigMapStringInterface(volumeUrl(node, disk.VolumeId()), "volume", "INFO")
+	if err != nil {
+		return err
+	}
+	if v, isSet := params["format"]; isSet {
+		disk.Format = QemuDiskFormat(v.(string))
+	}
+	if v, isSet := params["size"]; isSet {
+		disk.SizeInKibibytes = QemuDiskSize(v.(float64) / 1024)
+	}
+	return nil
+}
+
+// Returns all unused disks of the guest, including their size and format as reported by the storage.
+func (vmr *VmRef) UnusedDisks(c *Client) ([]QemuUnusedDisk, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return nil, err
+	}
+	config, err := c.GetVmConfig(vmr)
+	if err != nil {
+		return nil, err
+	}
+	disks := QemuUnusedDisk{}.mapToSDK(config)
+	for i := range disks {
+		if err = disks[i].readVolumeInfo(c, vmr.node); err != nil {
+			return nil, err
+		}
+	}
+	return disks, nil
+}
+
+// Attaches the unused disk unused[unusedId] to the guest as the disk with the given id.
+// Returns an error when the slot of the disk id is already in use.
+func (vmr *VmRef) AttachUnusedDisk(c *Client, unusedId uint, id QemuDiskId) error {
+	if c == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return err
+	}
+	pending, err := pendingGuestConfigFromApi(vmr, c)
+	if err != nil {
+		return err
+	}
+	if diskSlotUsed(pending, id) {
+		return errors.New(QemuUnusedDisk_Error_SlotUsed + string(id))
+	}
+	config, err := c.GetVmConfig(vmr)
+	if err != nil {
+		return err
+	}
+	disk, err := unusedDiskFromConfig(config, unusedId)
+	if err != nil {
+		return err
+	}
+	_, err = c.PutWithTask(map[string]interface{}{string(id): disk.VolumeId()}, "/nodes/"+vmr.node+"/"+vmr.vmType+"/"+strconv.Itoa(vmr.vmId)+"/config")
+	return err
+}
+
+// Returns true when the slot of the disk id is used by the current config or by a pending change.
+// The pending config lists every setting of the guest, including the ones without pending changes.
+func diskSlotUsed(pending []interface{}, id QemuDiskId) bool {
+	for _, e := range pending {
+		if setting, ok := e.(map[string]interface{}); ok && setting["key"] == string(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// Permanently deletes the volume of the unused disk unused[unusedId].
+func (vmr *VmRef) DeleteUnusedDisk(c *Client, unusedId uint) error {
+	if c == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return err
+	}
+	config, err := c.GetVmConfig(vmr)
+	if err != nil {
+		return err
+	}
+	if _, err = unusedDiskFromConfig(config, unusedId); err != nil {
+		return err
+	}
+	_, err = c.Unlink(vmr.node, vmr.vmId, "unused"+strconv.FormatUint(uint64(unusedId), 10), true)
+	return err
+}
+
+// Permanently deletes the volumes of all unused disks of the guest.
+func (vmr *VmRef) DeleteUnusedDisks(c *Client) error {
+	if c == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return err
+	}
+	config, err := c.GetVmConfig(vmr)
+	if err != nil {
+		return err
+	}
+	disks := QemuUnusedDisk{}.mapToSDK(config)
+	if len(disks) == 0 {
+		return nil
+	}
+	ids := make([]string, len(disks))
+	for i, e := range disks {
+		ids[i] = "unused" + strconv.FormatUint(uint64(e.Id), 10)
+	}
+	_, err = c.Unlink(vmr.node, vmr.vmId, strings.Join(ids, ","), true)
+	return err
+}
+
+func unusedDiskFromConfig(config map[string]interface{}, unusedId uint) (*QemuUnusedDisk, error) {
+	for _, e := range (QemuUnusedDisk{}).mapToSDK(config) {
+		if e.Id == unusedId {
+			return &e, nil
+		}
+	}
+	return nil, errors.New(QemuUnusedDisk_Error_NotFound + strconv.FormatUint(uint64(unusedId), 10))
+}
@@ -0,0 +1,61 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_QemuUnusedDisk_mapToSDK(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output []QemuUnusedDisk
+	}{
+		{name: `None`,
+			input:  map[string]interface{}{"scsi0": "local-lvm:vm-100-disk-0,size=10G"},
+			output: []QemuUnusedDisk{}},
+		{name: `Multiple`,
+			input: map[string]interface{}{
+				"scsi0":    "local-lvm:vm-100-disk-0,size=10G",
+				"unused10": "local:100/vm-100-disk-3.qcow2",
+				"unused0":  "local-lvm:vm-100-disk-1",
+				"unused2":  "nfs:100/vm-100-disk-2.vmdk",
+				"unusedx":  "local-lvm:vm-100-disk-4"},
+			output: []QemuUnusedDisk{
+				{Id: 0, Storage: "local-lvm", Volume: "vm-100-disk-1", Format: QemuDiskFormat_Raw},
+				{Id: 2, Storage: "nfs", Volume: "100/vm-100-disk-2.vmdk", Format: QemuDiskFormat_Vmdk},
+				{Id: 10, Storage: "local", Volume: "100/vm-100-disk-3.qcow2", Format: QemuDiskFormat_Qcow2}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, QemuUnusedDisk{}.mapToSDK(test.input))
+		})
+	}
+}
+
+func Test_QemuUnusedDisk_VolumeId(t *testing.T) {
+	require.Equal(t, "local:100/vm-100-disk-3.qcow2", QemuUnusedDisk{Storage: "local", Volume: "100/vm-100-disk-3.qcow2"}.VolumeId())
+}
+
+func Test_unusedDiskFromConfig(t *testing.T) {
+	config := map[string]interface{}{"unused1": "local-lvm:vm-100-disk-1"}
+	disk, err := unusedDiskFromConfig(config, 1)
+	require.NoError(t, err)
+	require.Equal(t, &QemuUnusedDisk{Id: 1, Storage: "local-lvm", Volume: "vm-100-disk-1", Format: QemuDiskFormat_Raw}, disk)
+	_, err = unusedDiskFromConfig(config, 0)
+	require.Equal(t, errors.New(QemuUnusedDisk_Error_NotFound+"0"), err)
+}
+
+func Test_diskSlotUsed(t *testing.T) {
+	pending := []interface{}{
+		map[string]interface{}{"key": "scsi0", "value": "local-lvm:vm-100-disk-0,size=8G"},
+		map[string]interface{}{"key": "scsi1", "pending": "local-lvm:vm-100-disk-1,size=4G"},
+		map[string]interface{}{"key": "unused0", "value": "local-lvm:vm-100-disk-2"},
+	}
+	require.True(t, diskSlotUsed(pending, "scsi0"))
+	require.True(t, diskSlotUsed(pending, "scsi1"))
+	require.False(t, diskSlotUsed(pending, "scsi2"))
+	require.False(t, diskSlotUsed(nil, "scsi0"))
+}
@@ -12,7 +12,8 @@ type QemuVirtIODisk struct {
 	Cache           QemuDiskCache     `json:"cache,omitempty"`
 	Discard         bool              `json:"discard"`
 	Format          QemuDiskFormat    `json:"format"`
-	Id              uint              `json:"id"` //Id is only returned and setting it has no effect
+	Id              uint              `json:"id"`                    //Id is only returned and setting it has no effect
+	ImportFrom      *QemuDiskImport   `json:"import_from,omitempty"` //ImportFrom is only used when creating the disk
 	IOThread        bool              `json:"iothread"`
 	LinkedDiskId    *uint             `json:"linked"` //LinkedCloneId is only returned and setting it has no effect
 	ReadOnly        bool              `json:"readonly"`
@@ -35,6 +36,7 @@ func (disk *QemuVirtIODisk) convertDataStructure() *qemuDisk {
 		fileSyntax:      disk.syntax,
 		Format:          disk.Format,
 		Id:              disk.Id,
+		ImportFrom:      disk.ImportFrom,
 		IOThread:        disk.IOThread,
 		LinkedDiskId:    disk.LinkedDiskId,
 		ReadOnly:        disk.ReadOnly,
@@ -114,6 +116,28 @@ func (disks QemuVirtIODisks) mapToIntMap() map[uint8]*QemuVirtIOStorage {
 	}
 }
 
+// Same as mapToIntMap, but the disks can be replaced through the returned pointers.
+func (disks *QemuVirtIODisks) mapToIntMapPointer() map[uint8]**QemuVirtIOStorage {
+	return map[uint8]**QemuVirtIOStorage{
+		0:  &disks.Disk_0,
+		1:  &disks.Disk_1,
+		2:  &disks.Disk_2,
+		3:  &disks.Disk_3,
+		4:  &disks.Disk_4,
+		5:  &disks.Disk_5,
+		6:  &disks.Disk_6,
+		7:  &disks.Disk_7,
+		8:  &disks.Disk_8,
+		9:  &disks.Disk_9,
+		10: &disks.Disk_10,
+		11: &disks.Disk_11,
+		12: &disks.Disk_12,
+		13: &disks.Disk_13,
+		14: &disks.Disk_14,
+		15: &disks.Disk_15,
+	}
+}
+
 func (QemuVirtIODisks) mapToStruct(params map[string]interface{}, linkedVmId *uint) *QemuVirtIODisks {
 	disks := QemuVirtIODisks{}
 	var structPopulated bool
@@ -207,7 +231,7 @@ func (disks QemuVirtIODisks) selectInitialResize(currentDisks *QemuVirtIODisks)
 	diskMap := disks.mapToIntMap()
 	currentDiskMap := tmpCurrentDisks.mapToIntMap()
 	for i := range diskMap {
-		if diskMap[i] != nil && diskMap[i].Disk != nil && diskMap[i].Disk.SizeInKibibytes%gibibyte != 0 && (currentDiskMap[i] == nil || currentDiskMap[i].Disk == nil || diskMap[i].Disk.SizeInKibibytes < currentDiskMap[i].Disk.SizeInKibibytes) {
+		if diskMap[i] != nil && diskMap[i].Disk != nil && (diskMap[i].Disk.SizeInKibibytes%gibibyte != 0 || (diskMap[i].Disk.ImportFrom != nil && diskMap[i].Disk.SizeInKibibytes != 0)) && (currentDiskMap[i] == nil || currentDiskMap[i].Disk == nil || diskMap[i].Disk.SizeInKibibytes < currentDiskMap[i].Disk.SizeInKibibytes) {
 			resize = append(resize, qemuDiskResize{
 				Id:              QemuDiskId("virtio" + strconv.Itoa(int(i))),
 				SizeInKibibytes: diskMap[i].Disk.SizeInKibibytes,
@@ -1068,6 +1068,11 @@ func Test_ConfigQemu_mapToAPI(t *testing.T) {
 				{name: `Disks.Ide.Disk_X.Disk.Format`,
 					config: &ConfigQemu{Disks: &QemuStorages{Ide: &QemuIdeDisks{Disk_1: &QemuIdeStorage{Disk: &QemuIdeDisk{Format: format_Raw}}}}},
 					output: map[string]interface{}{"ide1": ",backup=0,format=raw,replicate=0"}},
+				{name: `Disks.Ide.Disk_X.Disk.ImportFrom`,
+					config: &ConfigQemu{Disks: &QemuStorages{Ide: &QemuIdeDisks{Disk_1: &QemuIdeStorage{Disk: &QemuIdeDisk{
+						ImportFrom: &QemuDiskImport{Volume: "local:import/debian-12.qcow2"},
+						Storage:    "Test"}}}}},
+					output: map[string]interface{}{"ide1": "Test:0,import-from=local:import/debian-12.qcow2,backup=0,replicate=0"}},
 				{name: `Disks.Ide.Disk_X.Disk.Replicate`,
 					config: &ConfigQemu{Disks: &QemuStorages{Ide: &QemuIdeDisks{Disk_1: &QemuIdeStorage{Disk: &QemuIdeDisk{Replicate: true}}}}},
 					output: map[string]interface{}{"ide1": ",backup=0"}},
@@ -1694,6 +1699,11 @@ func Test_ConfigQemu_mapToAPI(t *testing.T) {
 				{name: `Disks.Sata.Disk_X.Disk.Format`,
 					config: &ConfigQemu{Disks: &QemuStorages{Sata: &QemuSataDisks{Disk_2: &QemuSataStorage{Disk: &QemuSataDisk{Format: format_Raw}}}}},
 					output: map[string]interface{}{"sata2": ",backup=0,format=raw,replicate=0"}},
+				{name: `Disks.Sata.Disk_X.Disk.ImportFrom`,
+					config: &ConfigQemu{Disks: &QemuStorages{Sata: &QemuSataDisks{Disk_2: &QemuSataStorage{Disk: &QemuSataDisk{
+						ImportFrom: &QemuDiskImport{Volume: "local:import/debian-12.qcow2"},
+						Storage:    "Test"}}}}},
+					output: map[string]interface{}{"sata2": "Test:0,import-from=local:import/debian-12.qcow2,backup=0,replicate=0"}},
 				{name: `Disks.Sata.Disk_X.Disk.Replicate`,
 					config: &ConfigQemu{Disks: &QemuStorages{Sata: &QemuSataDisks{Disk_2: &QemuSataStorage{Disk: &QemuSataDisk{Replicate: true}}}}},
 					output: map[string]interface{}{"sata2": ",backup=0"}},
@@ -2318,6 +2328,11 @@ func Test_ConfigQemu_mapToAPI(t *testing.T) {
 				{name: `Disks.Scsi.Disk_X.Disk.Format`,
 					config: &ConfigQemu{Disks: &QemuStorages{Scsi: &QemuScsiDisks{Disk_20: &QemuScsiStorage{Disk: &QemuScsiDisk{Format: format_Raw}}}}},
 					output: map[string]interface{}{"scsi20": ",backup=0,format=raw,replicate=0"}},
+				{name: `Disks.Scsi.Disk_X.Disk.ImportFrom`,
+					config: &ConfigQemu{Disks: &QemuStorages{Scsi: &QemuScsiDisks{Disk_20: &QemuScsiStorage{Disk: &QemuScsiDisk{
+						ImportFrom: &QemuDiskImport{Volume: "local:import/debian-12.qcow2"},
+						Storage:    "Test"}}}}},
+					output: map[string]interface{}{"scsi20": "Test:0,import-from=local:import/debian-12.qcow2,backup=0,replicate=0"}},
 				{name: `Disks.Scsi.Disk_X.Disk.IOThread`,
 					config: &ConfigQemu{Disks: &QemuStorages{Scsi: &QemuScsiDisks{Disk_21: &QemuScsiStorage{Disk: &QemuScsiDisk{IOThread: true}}}}},
 					output: map[string]interface{}{"scsi21": ",backup=0,iothread=1,replicate=0"}},
@@ -2966,6 +2981,11 @@ func Test_ConfigQemu_mapToAPI(t *testing.T) {
 				{name: `Disks.VirtIO.Disk_X.Disk.Format`,
 					config: &ConfigQemu{Disks: &QemuStorages{VirtIO: &QemuVirtIODisks{Disk_4: &QemuVirtIOStorage{Disk: &QemuVirtIODisk{Format: format_Raw}}}}},
 					output: map[string]interface{}{"virtio4": ",backup=0,format=raw,replicate=0"}},
+				{name: `Disks.VirtIO.Disk_X.Disk.ImportFrom`,
+					config: &ConfigQemu{Disks: &QemuStorages{VirtIO: &QemuVirtIODisks{Disk_4: &QemuVirtIOStorage{Disk: &QemuVirtIODisk{
+						ImportFrom: &QemuDiskImport{Volume: "local:import/debian-12.qcow2"},
+						Storage:    "Test"}}}}},
+					output: map[string]interface{}{"virtio4": "Test:0,import-from=local:import/debian-12.qcow2,backup=0,replicate=0"}},
 				{name: `Disks.VirtIO.Disk_X.Disk.IOThread`,
 					config: &ConfigQemu{Disks: &QemuStorages{VirtIO: &QemuVirtIODisks{Disk_4: &QemuVirtIOStorage{Disk: &QemuVirtIODisk{IOThread: true}}}}},
 					output: map[string]interface{}{"virtio4": ",backup=0,iothread=1,replicate=0"}},
@@ -0,0 +1,344 @@
+package proxmox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Storage replication job of a guest, configured in /cluster/replication.
+// Only storages that support replication (ZFS) can be replicated.
+type ConfigReplicationJob struct {
+	Comment  string             `json:"comment,omitempty"`
+	Enabled  bool               `json:"enabled"`
+	ID       ReplicationJobID   `json:"id"`
+	Rate     ReplicationJobRate `json:"rate,omitempty"`     // 0 is unlimited
+	Schedule CalendarEvent      `json:"schedule,omitempty"` // when empty the job runs every 15 minutes
+	Target   string             `json:"target"`             // the node the guest is replicated to, can not be changed after creation
+}
+
+func (config ConfigReplicationJob) mapToApiValues(create bool) (params map[string]interface{}) {
+	var deletions string
+	params = map[string]interface{}{
+		"disable": !config.Enabled,
+	}
+	if create {
+		params["id"] = string(config.ID)
+		params["target"] = config.Target
+		params["type"] = "local"
+	}
+	if config.Comment != "" {
+		params["comment"] = config.Comment
+	} else if !create {
+		deletions = AddToList(deletions, "comment")
+	}
+	if config.Rate != 0 {
+		params["rate"] = floatToTrimmedString(float64(config.Rate), 2)
+	} else if !create {
+		deletions = AddToList(deletions, "rate")
+	}
+	if config.Schedule != "" {
+		params["schedule"] = string(config.Schedule)
+	} else if !create {
+		deletions = AddToList(deletions, "schedule")
+	}
+	if !create && deletions != "" {
+		params["delete"] = deletions
+	}
+	return
+}
+
+func (ConfigReplicationJob) mapToSDK(params map[string]interface{}) (config ConfigReplicationJob) {
+	if v, isSet := params["comment"]; isSet {
+		config.Comment = v.(string)
+	}
+	config.Enabled = true
+	if v, isSet := params["disable"]; isSet {
+		config.Enabled = !apiBool(v)
+	}
+	if v, isSet := params["id"]; isSet {
+		config.ID = ReplicationJobID(v.(string))
+	}
+	if v, isSet := params["rate"]; isSet {
+		var tmp float64
+		switch rate := v.(type) {
+		case float64:
+			tmp = rate
+		case string:
+			tmp, _ = strconv.ParseFloat(rate, 32)
+		}
+		config.Rate = ReplicationJobRate(math.Round(tmp*100) / 100)
+	}
+	if v, isSet := params["schedule"]; isSet {
+		config.Schedule = CalendarEvent(v.(string))
+	}
+	if v, isSet := params["target"]; isSet {
+		config.Target = v.(string)
+	}
+	return
+}
+
+const ConfigReplicationJob_Error_TargetEmpty string = "target node may not be empty"
+
+func (config ConfigReplicationJob) Validate() error {
+	if err := config.ID.Validate(); err != nil {
+		return err
+	}
+	if config.Target == "" {
+		return errors.New(ConfigReplicationJob_Error_TargetEmpty)
+	}
+	if err := config.Rate.Validate(); err != nil {
+		return err
+	}
+	if config.Schedule != "" {
+		return config.Schedule.Validate()
+	}
+	return nil
+}
+
+func (config ConfigReplicationJob) Create(c *Client) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return config.Create_Unsafe(c)
+}
+
+// Create the replication job without validating the input, use ConfigReplicationJob.Create() to validate the input.
+func (config ConfigReplicationJob) Create_Unsafe(c *Client) error {
+	params := config.mapToApiValues(true)
+	if err := c.Post(params, "/cluster/replication"); err != nil {
+		params, _ := json.Marshal(&params)
+		return fmt.Errorf("error creating Replication Job: %v, (params: %v)", err, string(params))
+	}
+	return nil
+}
+
+func (config ConfigReplicationJob) Update(c *Client) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return config.Update_Unsafe(c)
+}
+
+// Update the replication job without validating the input, use ConfigReplicationJob.Update() to validate the input.
+func (config ConfigReplicationJob) Update_Unsafe(c *Client) error {
+	params := config.mapToApiValues(false)
+	if err := c.Put(params, "/cluster/replication/"+string(config.ID)); err != nil {
+		params, _ := json.Marshal(&params)
+		return fmt.Errorf("error updating Replication Job: %v, (params: %v)", err, string(params))
+	}
+	return nil
+}
+
+func NewConfigReplicationJobFromApi(id ReplicationJobID, c *Client) (*ConfigReplicationJob, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+	params, err := c.GetItemConfigMapStringInterface("/cluster/replication/"+string(id), "replication job", "CONFIG")
+	if err != nil {
+		return nil, err
+	}
+	config := ConfigReplicationJob{}.mapToSDK(params)
+	config.ID = id
+	return &config, nil
+}
+
+func NewConfigReplicationJobFromJson(input []byte) (*ConfigReplicationJob, error) {
+	config := &ConfigReplicationJob{}
+	err := json.Unmarshal(input, config)
+	return config, err
+}
+
+// Returns all replication jobs, sorted by id.
+func ListReplicationJobs(c *Client) ([]ConfigReplicationJob, error) {
+	list, err := c.GetItemListInterfaceArray("/cluster/replication")
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]ConfigReplicationJob, len(list))
+	for i, e := range list {
+		jobs[i] = ConfigReplicationJob{}.mapToSDK(e.(map[string]interface{}))
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID.less(jobs[j].ID) })
+	return jobs, nil
+}
+
+// The id of a replication job is formatted as "<guest id>-<job number>", e.g. "100-0".
+type ReplicationJobID string
+
+const (
+	ReplicationJobID_Error_Empty   string = "replication job id may not be empty"
+	ReplicationJobID_Error_Invalid string = "replication job id should be formatted as <guest id>-<job number>"
+)
+
+var regex_ReplicationJobID = regexp.MustCompile(`^([1-9][0-9]*)-(0|[1-9][0-9]*)$`)
+
+func NewReplicationJobID(guest, job uint) ReplicationJobID {
+	return ReplicationJobID(strconv.FormatUint(uint64(guest), 10) + "-" + strconv.FormatUint(uint64(job), 10))
+}
+
+// Deletes the replication job, the replicated data on the target node is removed by the replication runner.
+// When keep is true the replicated data on the target node is kept.
+func (id ReplicationJobID) Delete(c *Client, keep bool) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+	url := "/cluster/replication/" + string(id)
+	if keep {
+		url += "?keep=1"
+	}
+	return c.Delete(url)
+}
+
+func (id ReplicationJobID) Exists(c *Client) (bool, error) {
+	list, err := c.GetItemListInterfaceArray("/cluster/replication")
+	if err != nil {
+		return false, err
+	}
+	return ItemInKeyOfArray(list, "id", string(id)), nil
+}
+
+// Returns the guest and job number of the id, both are 0 when the id is invalid.
+func (id ReplicationJobID) Parse() (uint, uint) {
+	match := regex_ReplicationJobID.FindStringSubmatch(string(id))
+	if match == nil {
+		return 0, 0
+	}
+	guest, _ := strconv.ParseUint(match[1], 10, 32)
+	job, _ := strconv.ParseUint(match[2], 10, 32)
+	return uint(guest), uint(job)
+}
+
+func (id ReplicationJobID) Validate() error {
+	if id == "" {
+		return errors.New(ReplicationJobID_Error_Empty)
+	}
+	if !regex_ReplicationJobID.MatchString(string(id)) {
+		return errors.New(ReplicationJobID_Error_Invalid)
+	}
+	return nil
+}
+
+func (id ReplicationJobID) less(other ReplicationJobID) bool {
+	guest, job := id.Parse()
+	otherGuest, otherJob := other.Parse()
+	if guest != otherGuest {
+		return guest < otherGuest
+	}
+	return job < otherJob
+}
+
+const ReplicationJobRate_Error_Invalid string = "rate may not be lower then 1 except for 0"
+
+// Bandwidth limit of a replication job in MB/s.
+type ReplicationJobRate float32
+
+func (rate ReplicationJobRate) Validate() error {
+	if rate != 0 && rate < 1 {
+		return errors.New(ReplicationJobRate_Error_Invalid)
+	}
+	return nil
+}
+
+// Status of a replication job, as reported by the source node of the guest.
+type ReplicationJobStatus struct {
+	Duration  time.Duration    `json:"duration"` // duration of the last sync
+	Enabled   bool             `json:"enabled"`
+	Error     string           `json:"error,omitempty"`
+	FailCount uint             `json:"fail_count"`
+	Guest     uint             `json:"guest"`
+	ID        ReplicationJobID `json:"id"`
+	LastSync  *time.Time       `json:"last_sync,omitempty"` // nil when the job never synced successfully
+	LastTry   *time.Time       `json:"last_try,omitempty"`
+	NextSync  *time.Time       `json:"next_sync,omitempty"`
+	Running   bool             `json:"running"`
+	Source    string           `json:"source"`
+	Target    string           `json:"target"`
+}
+
+func (ReplicationJobStatus) mapToSDK(params map[string]interface{}) (status ReplicationJobStatus) {
+	unixTime := func(v interface{}) *time.Time {
+		seconds, _ := v.(float64)
+		if seconds <= 0 {
+			return nil
+		}
+		t := time.Unix(int64(seconds), 0)
+		return &t
+	}
+	if v, isSet := params["duration"]; isSet {
+		status.Duration = time.Duration(v.(float64) * float64(time.Second))
+	}
+	status.Enabled = true
+	if v, isSet := params["disable"]; isSet {
+		status.Enabled = !apiBool(v)
+	}
+	if v, isSet := params["error"]; isSet {
+		status.Error = strings.TrimSpace(v.(string))
+	}
+	if v, isSet := params["fail_count"]; isSet {
+		status.FailCount = uint(v.(float64))
+	}
+	if v, isSet := params["guest"]; isSet {
+		status.Guest = uint(v.(float64))
+	}
+	if v, isSet := params["id"]; isSet {
+		status.ID = ReplicationJobID(v.(string))
+	}
+	if v, isSet := params["last_sync"]; isSet {
+		status.LastSync = unixTime(v)
+	}
+	if v, isSet := params["last_try"]; isSet {
+		status.LastTry = unixTime(v)
+	}
+	if v, isSet := params["next_sync"]; isSet {
+		status.NextSync = unixTime(v)
+	}
+	if _, isSet := params["pid"]; isSet {
+		status.Running = true
+	}
+	if v, isSet := params["source"]; isSet {
+		status.Source = v.(string)
+	}
+	if v, isSet := params["target"]; isSet {
+		status.Target = v.(string)
+	}
+	return
+}
+
+// Returns true when the job is enabled and either failed its last sync, or did not sync successfully within maxAge before now.
+func (status ReplicationJobStatus) Stalled(now time.Time, maxAge time.Duration) bool {
+	if !status.Enabled {
+		return false
+	}
+	if status.FailCount > 0 {
+		return true
+	}
+	return status.LastSync == nil || now.Sub(*status.LastSync) > maxAge
+}
+
+// Returns the status of the replication jobs of the guests on the node, sorted by id.
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/replication
+func ListReplicationJobStatuses(c *Client, node string) ([]ReplicationJobStatus, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	list, err := c.GetItemListInterfaceArray("/nodes/" + node + "/replication")
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]ReplicationJobStatus, len(list))
+	for i, e := range list {
+		statuses[i] = ReplicationJobStatus{}.mapToSDK(e.(map[string]interface{}))
+		if statuses[i].Source == "" {
+			statuses[i].Source = node
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ID.less(statuses[j].ID) })
+	return statuses, nil
+}
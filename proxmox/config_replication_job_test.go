@@ -0,0 +1,199 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigReplicationJob_mapToApiValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  ConfigReplicationJob
+		create bool
+		output map[string]interface{}
+	}{
+		{name: `Create minimal`,
+			input:  ConfigReplicationJob{Enabled: true, ID: "100-0", Target: "pve2"},
+			create: true,
+			output: map[string]interface{}{"disable": false, "id": "100-0", "target": "pve2", "type": "local"}},
+		{name: `Create full`,
+			input:  ConfigReplicationJob{Comment: "zfs", ID: "100-1", Rate: 50, Schedule: "*/5", Target: "pve3"},
+			create: true,
+			output: map[string]interface{}{"comment": "zfs", "disable": true, "id": "100-1", "rate": "50", "schedule": "*/5", "target": "pve3", "type": "local"}},
+		{name: `Update full`,
+			input:  ConfigReplicationJob{Comment: "zfs", Enabled: true, ID: "100-1", Rate: 50, Schedule: "*/5", Target: "pve3"},
+			output: map[string]interface{}{"comment": "zfs", "disable": false, "rate": "50", "schedule": "*/5"}},
+		{name: `Update fractional rate`,
+			input:  ConfigReplicationJob{Enabled: true, ID: "100-1", Rate: 1.5, Schedule: "*/5", Target: "pve3"},
+			output: map[string]interface{}{"delete": "comment", "disable": false, "rate": "1.5", "schedule": "*/5"}},
+		{name: `Update delete`,
+			input:  ConfigReplicationJob{Enabled: true, ID: "100-1", Target: "pve3"},
+			output: map[string]interface{}{"disable": false, "delete": "comment,rate,schedule"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.mapToApiValues(test.create))
+		})
+	}
+}
+
+func Test_ConfigReplicationJob_mapToSDK(t *testing.T) {
+	require.Equal(t,
+		ConfigReplicationJob{Comment: "zfs", ID: "100-1", Rate: 50, Schedule: "*/5", Target: "pve3"},
+		ConfigReplicationJob{}.mapToSDK(map[string]interface{}{
+			"comment":  "zfs",
+			"disable":  float64(1),
+			"guest":    float64(100),
+			"id":       "100-1",
+			"jobnum":   float64(1),
+			"rate":     "50",
+			"schedule": "*/5",
+			"target":   "pve3",
+			"type":     "local"}))
+	require.Equal(t,
+		ConfigReplicationJob{Enabled: true, ID: "101-0", Rate: 10, Target: "pve2"},
+		ConfigReplicationJob{}.mapToSDK(map[string]interface{}{"id": "101-0", "rate": float64(10), "target": "pve2"}))
+	require.Equal(t,
+		ConfigReplicationJob{Enabled: true, ID: "101-0", Rate: 1.5, Target: "pve2"},
+		ConfigReplicationJob{}.mapToSDK(map[string]interface{}{"id": "101-0", "rate": "1.5", "target": "pve2"}))
+	require.Equal(t,
+		ConfigReplicationJob{Enabled: true, ID: "101-0", Rate: 2.25, Target: "pve2"},
+		ConfigReplicationJob{}.mapToSDK(map[string]interface{}{"id": "101-0", "rate": float64(2.25), "target": "pve2"}))
+}
+
+func Test_ConfigReplicationJob_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  ConfigReplicationJob
+		output error
+	}{
+		{name: `Valid`,
+			input: ConfigReplicationJob{ID: "100-0", Target: "pve2"}},
+		{name: `Valid Schedule`,
+			input: ConfigReplicationJob{ID: "100-0", Schedule: "sat 02:00", Target: "pve2"}},
+		{name: `Valid Rate`,
+			input: ConfigReplicationJob{ID: "100-0", Rate: 1.5, Target: "pve2"}},
+		{name: `Invalid ID`,
+			input:  ConfigReplicationJob{ID: "100", Target: "pve2"},
+			output: errors.New(ReplicationJobID_Error_Invalid)},
+		{name: `Invalid Target`,
+			input:  ConfigReplicationJob{ID: "100-0"},
+			output: errors.New(ConfigReplicationJob_Error_TargetEmpty)},
+		{name: `Invalid Rate`,
+			input:  ConfigReplicationJob{ID: "100-0", Rate: 0.5, Target: "pve2"},
+			output: errors.New(ReplicationJobRate_Error_Invalid)},
+		{name: `Invalid Schedule`,
+			input:  ConfigReplicationJob{ID: "100-0", Schedule: "*-*-*-*", Target: "pve2"},
+			output: errors.New(CalendarEvent_Error_Invalid + "*-*-*-*")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
+
+func Test_ReplicationJobID(t *testing.T) {
+	require.Equal(t, ReplicationJobID("100-2"), NewReplicationJobID(100, 2))
+	guest, job := ReplicationJobID("100-2").Parse()
+	require.Equal(t, uint(100), guest)
+	require.Equal(t, uint(2), job)
+	guest, job = ReplicationJobID("abc").Parse()
+	require.Equal(t, uint(0), guest)
+	require.Equal(t, uint(0), job)
+	require.True(t, ReplicationJobID("100-2").less("100-10"))
+	require.True(t, ReplicationJobID("99-5").less("100-0"))
+	require.False(t, ReplicationJobID("100-0").less("100-0"))
+}
+
+func Test_ReplicationJobID_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  ReplicationJobID
+		output error
+	}{
+		{name: `Valid`, input: "100-0"},
+		{name: `Valid job number`, input: "999999-12"},
+		{name: `Invalid Empty`, output: errors.New(ReplicationJobID_Error_Empty)},
+		{name: `Invalid guest 0`, input: "0-1", output: errors.New(ReplicationJobID_Error_Invalid)},
+		{name: `Invalid leading zero`, input: "100-01", output: errors.New(ReplicationJobID_Error_Invalid)},
+		{name: `Invalid characters`, input: "vm100-0", output: errors.New(ReplicationJobID_Error_Invalid)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
+
+func Test_ReplicationJobStatus_mapToSDK(t *testing.T) {
+	lastSync := time.Unix(1714557600, 0)
+	lastTry := time.Unix(1714558500, 0)
+	nextSync := time.Unix(1714558800, 0)
+	require.Equal(t,
+		ReplicationJobStatus{
+			Duration:  2500 * time.Millisecond,
+			Enabled:   true,
+			Error:     "command 'zfs snapshot' failed",
+			FailCount: 3,
+			Guest:     100,
+			ID:        "100-0",
+			LastSync:  &lastSync,
+			LastTry:   &lastTry,
+			NextSync:  &nextSync,
+			Source:    "pve1",
+			Target:    "pve2"},
+		ReplicationJobStatus{}.mapToSDK(map[string]interface{}{
+			"duration":   float64(2.5),
+			"error":      "command 'zfs snapshot' failed\n",
+			"fail_count": float64(3),
+			"guest":      float64(100),
+			"id":         "100-0",
+			"last_sync":  float64(1714557600),
+			"last_try":   float64(1714558500),
+			"next_sync":  float64(1714558800),
+			"source":     "pve1",
+			"target":     "pve2"}))
+	require.Equal(t,
+		ReplicationJobStatus{ID: "101-0", Guest: 101, Running: true, Target: "pve2"},
+		ReplicationJobStatus{}.mapToSDK(map[string]interface{}{
+			"disable":   float64(1),
+			"guest":     float64(101),
+			"id":        "101-0",
+			"last_sync": float64(0),
+			"pid":       float64(1234),
+			"target":    "pve2"}))
+}
+
+func Test_ReplicationJobStatus_Stalled(t *testing.T) {
+	now := time.Unix(1714560000, 0)
+	recent := now.Add(-10 * time.Minute)
+	old := now.Add(-2 * time.Hour)
+	tests := []struct {
+		name   string
+		input  ReplicationJobStatus
+		output bool
+	}{
+		{name: `Healthy`,
+			input: ReplicationJobStatus{Enabled: true, LastSync: &recent}},
+		{name: `Disabled`,
+			input: ReplicationJobStatus{FailCount: 2}},
+		{name: `Failed`,
+			input:  ReplicationJobStatus{Enabled: true, FailCount: 1, LastSync: &recent},
+			output: true},
+		{name: `Never synced`,
+			input:  ReplicationJobStatus{Enabled: true},
+			output: true},
+		{name: `Too old`,
+			input:  ReplicationJobStatus{Enabled: true, LastSync: &old},
+			output: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Stalled(now, time.Hour))
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package proxmox
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ConfigSDNController describes the SDN Controller configurable element
+type ConfigSDNController struct {
+	Controller              string `json:"controller"`
+	Type                    string `json:"type"`
+	ASN                     uint32 `json:"asn,omitempty"`
+	BGPMultipathAsPathRelax bool   `json:"bgp-multipath-as-path-relax,omitempty"`
+	EBGP                    bool   `json:"ebgp,omitempty"`
+	EBGPMultihop            int    `json:"ebgp-multihop,omitempty"`
+	ISISDomain              string `json:"isis-domain,omitempty"`
+	ISISIfaces              string `json:"isis-ifaces,omitempty"`
+	ISISNet                 string `json:"isis-net,omitempty"`
+	Loopback                string `json:"loopback,omitempty"`
+	Node                    string `json:"node,omitempty"`
+	Peers                   string `json:"peers,omitempty"`
+	// Pass a string of attributes to be deleted from the remote object
+	Delete string `json:"delete,omitempty"`
+	// Digest allows for a form of optimistic locking
+	Digest string `json:"digest,omitempty"`
+}
+
+func NewConfigSDNControllerFromJson(input []byte) (config *ConfigSDNController, err error) {
+	config = &ConfigSDNController{}
+	err = json.Unmarshal([]byte(input), config)
+	return
+}
+
+func (config *ConfigSDNController) CreateWithValidate(id string, client *Client) (err error) {
+	err = config.Validate(id, true, client)
+	if err != nil {
+		return
+	}
+	return config.Create(id, client)
+}
+
+func (config *ConfigSDNController) Create(id string, client *Client) (err error) {
+	config.Controller = id
+	params := config.mapToApiValues(true)
+	err = client.CreateSDNController(params)
+	if err != nil {
+		params, _ := json.Marshal(&params)
+		return fmt.Errorf("error creating SDN Controller: %v, (params: %v)", err, string(params))
+	}
+	return
+}
+
+func (config *ConfigSDNController) UpdateWithValidate(id string, client *Client) (err error) {
+	err = config.Validate(id, false, client)
+	if err != nil {
+		return
+	}
+	return config.Update(id, client)
+}
+
+func (config *ConfigSDNController) Update(id string, client *Client) (err error) {
+	config.Controller = id
+	params := config.mapToApiValues(false)
+	err = client.UpdateSDNController(id, params)
+	if err != nil {
+		params, _ := json.Marshal(&params)
+		return fmt.Errorf("error updating SDN Controller: %v, (params: %v)", err, string(params))
+	}
+	return
+}
+
+func (c *ConfigSDNController) Validate(id string, create bool, client *Client) (err error) {
+	exists, err := client.CheckSDNControllerExistance(id)
+	if err != nil {
+		return
+	}
+	if exists && create {
+		return ErrorItemExists(id, "controller")
+	}
+	if !exists && !create {
+		return ErrorItemNotExists(id, "controller")
+	}
+	return c.validate(create)
+}
+
+// validate checks the settings of the controller, without checking if it exists.
+func (c *ConfigSDNController) validate(create bool) (err error) {
+	err = ValidateStringInArray([]string{"bgp", "evpn", "faucet", "isis"}, c.Type, "type")
+	if err != nil {
+		return
+	}
+	switch c.Type {
+	case "evpn":
+		if create {
+			if c.ASN == 0 {
+				return ErrorKeyEmpty("asn")
+			}
+			if c.Peers == "" {
+				return ErrorKeyEmpty("peers")
+			}
+		}
+	case "bgp":
+		if create {
+			if c.ASN == 0 {
+				return ErrorKeyEmpty("asn")
+			}
+			if c.Node == "" {
+				return ErrorKeyEmpty("node")
+			}
+			if c.Peers == "" {
+				return ErrorKeyEmpty("peers")
+			}
+		}
+	case "isis":
+		if create {
+			if c.Node == "" {
+				return ErrorKeyEmpty("node")
+			}
+			if c.ISISDomain == "" {
+				return ErrorKeyEmpty("isis-domain")
+			}
+			if c.ISISIfaces == "" {
+				return ErrorKeyEmpty("isis-ifaces")
+			}
+			if c.ISISNet == "" {
+				return ErrorKeyEmpty("isis-net")
+			}
+		}
+	}
+	if c.EBGPMultihop != 0 {
+		err = ValidateIntInRange(1, 255, c.EBGPMultihop, "ebgp-multihop")
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (config *ConfigSDNController) mapToApiValues(create bool) (params map[string]interface{}) {
+	d, _ := json.Marshal(config)
+	json.Unmarshal(d, &params)
+
+	boolsToFix := []string{
+		"bgp-multipath-as-path-relax",
+		"ebgp",
+	}
+	for _, key := range boolsToFix {
+		if v, has := params[key]; has {
+			params[key] = Btoi(v.(bool))
+		}
+	}
+	// The controller and type may only be set during creation
+	if !create {
+		delete(params, "controller")
+		delete(params, "type")
+	}
+	return
+}
@@ -0,0 +1,65 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigSDNController_mapToApiValues(t *testing.T) {
+	config := ConfigSDNController{Controller: "evpn1", Type: "evpn", ASN: 65000, Peers: "10.0.0.1,10.0.0.2", BGPMultipathAsPathRelax: true}
+	require.Equal(t,
+		map[string]interface{}{"controller": "evpn1", "type": "evpn", "asn": float64(65000), "peers": "10.0.0.1,10.0.0.2", "bgp-multipath-as-path-relax": 1},
+		config.mapToApiValues(true))
+	require.Equal(t,
+		map[string]interface{}{"asn": float64(65000), "peers": "10.0.0.1,10.0.0.2", "bgp-multipath-as-path-relax": 1},
+		config.mapToApiValues(false))
+}
+
+func Test_ConfigSDNController_validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  ConfigSDNController
+		create bool
+		output error
+	}{
+		{name: `Valid evpn`,
+			input:  ConfigSDNController{Type: "evpn", ASN: 65000, Peers: "10.0.0.1"},
+			create: true},
+		{name: `Valid bgp`,
+			input:  ConfigSDNController{Type: "bgp", ASN: 65000, Node: "pve", Peers: "10.0.0.1", EBGP: true, EBGPMultihop: 2},
+			create: true},
+		{name: `Valid isis`,
+			input:  ConfigSDNController{Type: "isis", Node: "pve", ISISDomain: "isis1", ISISIfaces: "eno1", ISISNet: "49.0001.1921.6800.1002.00"},
+			create: true},
+		{name: `Valid update`,
+			input: ConfigSDNController{Type: "evpn"}},
+		{name: `Invalid type`,
+			input:  ConfigSDNController{Type: "ospf"},
+			output: ValidateStringInArray([]string{"bgp", "evpn", "faucet", "isis"}, "ospf", "type")},
+		{name: `Invalid evpn asn`,
+			input:  ConfigSDNController{Type: "evpn", Peers: "10.0.0.1"},
+			create: true,
+			output: ErrorKeyEmpty("asn")},
+		{name: `Invalid evpn peers`,
+			input:  ConfigSDNController{Type: "evpn", ASN: 65000},
+			create: true,
+			output: ErrorKeyEmpty("peers")},
+		{name: `Invalid bgp node`,
+			input:  ConfigSDNController{Type: "bgp", ASN: 65000, Peers: "10.0.0.1"},
+			create: true,
+			output: ErrorKeyEmpty("node")},
+		{name: `Invalid isis net`,
+			input:  ConfigSDNController{Type: "isis", Node: "pve", ISISDomain: "isis1", ISISIfaces: "eno1"},
+			create: true,
+			output: ErrorKeyEmpty("isis-net")},
+		{name: `Invalid ebgp-multihop`,
+			input:  ConfigSDNController{Type: "bgp", EBGPMultihop: 256},
+			output: ValidateIntInRange(1, 255, 256, "ebgp-multihop")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.validate(test.create))
+		})
+	}
+}
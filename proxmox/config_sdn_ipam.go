@@ -0,0 +1,122 @@
+package proxmox
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ConfigSDNIpam describes the SDN IPAM configurable element
+type ConfigSDNIpam struct {
+	Ipam string `json:"ipam"`
+	Type string `json:"type"`
+	// Id of the section the subnets are created in, only used by phpIPAM
+	Section int    `json:"section,omitempty"`
+	Token   string `json:"token,omitempty"`
+	URL     string `json:"url,omitempty"`
+	// Pass a string of attributes to be deleted from the remote object
+	Delete string `json:"delete,omitempty"`
+	// Digest allows for a form of optimistic locking
+	Digest string `json:"digest,omitempty"`
+}
+
+func NewConfigSDNIpamFromJson(input []byte) (config *ConfigSDNIpam, err error) {
+	config = &ConfigSDNIpam{}
+	err = json.Unmarshal([]byte(input), config)
+	return
+}
+
+func (config *ConfigSDNIpam) CreateWithValidate(id string, client *Client) (err error) {
+	err = config.Validate(id, true, client)
+	if err != nil {
+		return
+	}
+	return config.Create(id, client)
+}
+
+func (config *ConfigSDNIpam) Create(id string, client *Client) (err error) {
+	config.Ipam = id
+	params := config.mapToApiValues(true)
+	err = client.CreateSDNIpam(params)
+	if err != nil {
+		params, _ := json.Marshal(&params)
+		return fmt.Errorf("error creating SDN IPAM: %v, (params: %v)", err, string(params))
+	}
+	return
+}
+
+func (config *ConfigSDNIpam) UpdateWithValidate(id string, client *Client) (err error) {
+	err = config.Validate(id, false, client)
+	if err != nil {
+		return
+	}
+	return config.Update(id, client)
+}
+
+func (config *ConfigSDNIpam) Update(id string, client *Client) (err error) {
+	config.Ipam = id
+	params := config.mapToApiValues(false)
+	err = client.UpdateSDNIpam(id, params)
+	if err != nil {
+		params, _ := json.Marshal(&params)
+		return fmt.Errorf("error updating SDN IPAM: %v, (params: %v)", err, string(params))
+	}
+	return
+}
+
+func (c *ConfigSDNIpam) Validate(id string, create bool, client *Client) (err error) {
+	exists, err := client.CheckSDNIpamExistance(id)
+	if err != nil {
+		return
+	}
+	if exists && create {
+		return ErrorItemExists(id, "ipam")
+	}
+	if !exists && !create {
+		return ErrorItemNotExists(id, "ipam")
+	}
+	return c.validate(create)
+}
+
+// validate checks the settings of the IPAM, without checking if it exists.
+func (c *ConfigSDNIpam) validate(create bool) (err error) {
+	err = ValidateStringInArray([]string{"netbox", "phpipam", "pve"}, c.Type, "type")
+	if err != nil {
+		return
+	}
+	switch c.Type {
+	case "netbox":
+		if create {
+			if c.URL == "" {
+				return ErrorKeyEmpty("url")
+			}
+			if c.Token == "" {
+				return ErrorKeyEmpty("token")
+			}
+		}
+	case "phpipam":
+		if create {
+			if c.URL == "" {
+				return ErrorKeyEmpty("url")
+			}
+			if c.Token == "" {
+				return ErrorKeyEmpty("token")
+			}
+			err = ValidateIntGreater(0, c.Section, "section")
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func (config *ConfigSDNIpam) mapToApiValues(create bool) (params map[string]interface{}) {
+	d, _ := json.Marshal(config)
+	json.Unmarshal(d, &params)
+	// The ipam and type may only be set during creation
+	if !create {
+		delete(params, "ipam")
+		delete(params, "type")
+	}
+	return
+}
@@ -0,0 +1,58 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigSDNIpam_mapToApiValues(t *testing.T) {
+	config := ConfigSDNIpam{Ipam: "phpipam1", Type: "phpipam", Section: 3, Token: "secret", URL: "https://ipam.example.com/api/pve"}
+	require.Equal(t,
+		map[string]interface{}{"ipam": "phpipam1", "type": "phpipam", "section": float64(3), "token": "secret", "url": "https://ipam.example.com/api/pve"},
+		config.mapToApiValues(true))
+	require.Equal(t,
+		map[string]interface{}{"section": float64(3), "token": "secret", "url": "https://ipam.example.com/api/pve"},
+		config.mapToApiValues(false))
+}
+
+func Test_ConfigSDNIpam_validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  ConfigSDNIpam
+		create bool
+		output error
+	}{
+		{name: `Valid pve`,
+			input:  ConfigSDNIpam{Type: "pve"},
+			create: true},
+		{name: `Valid netbox`,
+			input:  ConfigSDNIpam{Type: "netbox", Token: "secret", URL: "https://netbox.example.com/api"},
+			create: true},
+		{name: `Valid phpipam`,
+			input:  ConfigSDNIpam{Type: "phpipam", Section: 1, Token: "secret", URL: "https://ipam.example.com/api/pve"},
+			create: true},
+		{name: `Valid update`,
+			input: ConfigSDNIpam{Type: "phpipam"}},
+		{name: `Invalid type`,
+			input:  ConfigSDNIpam{Type: "infoblox"},
+			output: ValidateStringInArray([]string{"netbox", "phpipam", "pve"}, "infoblox", "type")},
+		{name: `Invalid netbox url`,
+			input:  ConfigSDNIpam{Type: "netbox", Token: "secret"},
+			create: true,
+			output: ErrorKeyEmpty("url")},
+		{name: `Invalid netbox token`,
+			input:  ConfigSDNIpam{Type: "netbox", URL: "https://netbox.example.com/api"},
+			create: true,
+			output: ErrorKeyEmpty("token")},
+		{name: `Invalid phpipam section`,
+			input:  ConfigSDNIpam{Type: "phpipam", Token: "secret", URL: "https://ipam.example.com/api/pve"},
+			create: true,
+			output: ValidateIntGreater(0, 0, "section")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.validate(test.create))
+		})
+	}
+}
@@ -137,6 +137,24 @@ func (c *ConfigSDNZone) Validate(id string, create bool, client *Client) (err er
 			return
 		}
 	}
+	if c.Controller != "" {
+		exists, err = client.CheckSDNControllerExistance(c.Controller)
+		if err != nil {
+			return
+		}
+		if !exists {
+			return ErrorItemNotExists(c.Controller, "controller")
+		}
+	}
+	if c.IPAM != "" {
+		exists, err = client.CheckSDNIpamExistance(c.IPAM)
+		if err != nil {
+			return
+		}
+		if !exists {
+			return ErrorItemNotExists(c.IPAM, "ipam")
+		}
+	}
 	return
 }
 
@@ -26,6 +26,7 @@ var storageContentTypes = map[string]interface{}{
 	"zfs-over-iscsi": []bool{false, false, true, false, false, false},
 	"zfs":            []bool{false, true, true, false, false, false},
 	"pbs":            []bool{true, false, false, false, false, false},
+	"esxi":           []bool{false, false, false, false, false, false},
 }
 
 type ConfigStorageContent struct {
@@ -93,6 +94,28 @@ func (b *ConfigStorageBackupRetention) MapStorageBackupRetention() string {
 	return "keep-all=1"
 }
 
+// Maps the keep-* settings of prune-backups, returns nil when all backups are kept.
+func (ConfigStorageBackupRetention) mapToSDK(prune []string) *ConfigStorageBackupRetention {
+	if inArray(prune, "keep-all=1") {
+		return nil
+	}
+	retentionSettings := make(map[string]int)
+	for _, e := range prune {
+		a := strings.Split(e, "=")
+		if len(a) == 2 {
+			retentionSettings[a[0]], _ = strconv.Atoi(a[1])
+		}
+	}
+	return &ConfigStorageBackupRetention{
+		Daily:   util.Pointer(retentionSettings["keep-daily"]),
+		Hourly:  util.Pointer(retentionSettings["keep-hourly"]),
+		Last:    util.Pointer(retentionSettings["keep-last"]),
+		Monthly: util.Pointer(retentionSettings["keep-monthly"]),
+		Weekly:  util.Pointer(retentionSettings["keep-weekly"]),
+		Yearly:  util.Pointer(retentionSettings["keep-yearly"]),
+	}
+}
+
 func (b *ConfigStorageBackupRetention) Validate() (err error) {
 	if b == nil {
 		return nil
@@ -278,6 +301,13 @@ func (pbs *ConfigStoragePBS) SetDefaults() {
 	}
 }
 
+type ConfigStorageESXi struct {
+	Server               string  `json:"server"`
+	Username             string  `json:"username"`
+	Password             *string `json:"password,omitempty"`
+	SkipCertVerification bool    `json:"skip-cert-verification,omitempty"`
+}
+
 // Storage options for the Proxmox API
 type ConfigStorage struct {
 	ID              string                        `json:"id"`
@@ -296,6 +326,7 @@ type ConfigStorage struct {
 	ZFSoverISCSI    *ConfigStorageZFSoverISCSI    `json:"zfs-over-iscsi,omitempty"`
 	ZFS             *ConfigStorageZFS             `json:"zfs,omitempty"`
 	PBS             *ConfigStoragePBS             `json:"pbs,omitempty"`
+	ESXi            *ConfigStorageESXi            `json:"esxi,omitempty"`
 	Content         *ConfigStorageContent         `json:"content,omitempty"`
 	BackupRetention *ConfigStorageBackupRetention `json:"backupretention,omitempty"`
 }
@@ -367,7 +398,7 @@ func (newConfig *ConfigStorage) Validate(id string, create bool, client *Client)
 		return ErrorItemNotExists(id, "storage")
 	}
 
-	err = ValidateStringInArray([]string{"directory", "lvm", "lvm-thin", "nfs", "smb", "glusterfs", "iscsi", "cephfs", "rbd", "zfs-over-iscsi", "zfs", "pbs"}, newConfig.Type, "type")
+	err = ValidateStringInArray([]string{"directory", "lvm", "lvm-thin", "nfs", "smb", "glusterfs", "iscsi", "cephfs", "rbd", "zfs-over-iscsi", "zfs", "pbs", "esxi"}, newConfig.Type, "type")
 	if err != nil {
 		return
 	}
@@ -725,10 +756,36 @@ func (newConfig *ConfigStorage) Validate(id string, create bool, client *Client)
 				return
 			}
 		}
+	case "esxi":
+		if exists && newConfig.ESXi != nil {
+			err = ValidateStringsEqual(newConfig.ESXi.Server, currentConfig.ESXi.Server, "esxi:{ server }")
+			if err != nil {
+				return
+			}
+		} else if !exists {
+			if newConfig.ESXi == nil {
+				return ErrorKeyEmpty("esxi")
+			} else {
+				err = ValidateStringNotEmpty(newConfig.ESXi.Server, "esxi:{ server }")
+				if err != nil {
+					return
+				}
+				if newConfig.ESXi.Password == nil {
+					return ErrorKeyNotSet("esxi:{ password }")
+				}
+			}
+		}
+		if newConfig.ESXi != nil {
+			err = ValidateStringNotEmpty(newConfig.ESXi.Username, "esxi:{ username }")
+			if err != nil {
+				return
+			}
+		}
 	}
-	if !inArray([]string{"pbs", "zfs-over-iscsi"}, newConfig.Type) {
+	if !inArray([]string{"pbs", "zfs-over-iscsi", "esxi"}, newConfig.Type) {
 		// pbs has a hardcoded content type, of type backup.
 		// zfs-over-iscsi has a hardcoded content type, of type diskimage.
+		// esxi has a hardcoded content type, of type import.
 		if exists && newConfig.Content != nil {
 			err = newConfig.Content.Validate(newConfig.Type)
 			if err != nil {
@@ -922,9 +979,24 @@ func (config *ConfigStorage) mapToApiValues(create bool) (params map[string]inte
 		config.Content = &ConfigStorageContent{
 			Backup: util.Pointer(true),
 		}
+	case "esxi":
+		if config.ESXi != nil {
+			params["username"] = config.ESXi.Username
+			params["skip-cert-verification"] = config.ESXi.SkipCertVerification
+			if create {
+				params["server"] = config.ESXi.Server
+			}
+			if config.ESXi.Password != nil {
+				params["password"] = *config.ESXi.Password
+			}
+		}
 	}
 
-	params["content"] = config.Content.MapStorageContent(storageContentTypes[config.Type].([]bool))
+	if config.Type == "esxi" {
+		params["content"] = string(ContentType_Import)
+	} else {
+		params["content"] = config.Content.MapStorageContent(storageContentTypes[config.Type].([]bool))
+	}
 
 	if config.BackupRetention != nil {
 		if storageContentTypes[config.Type].([]bool)[0] {
@@ -1146,9 +1218,18 @@ func NewConfigStorageFromApi(storageid string, client *Client) (config *ConfigSt
 		if _, isSet := rawConfig["namespace"]; isSet {
 			config.PBS.Namespace = rawConfig["namespace"].(string)
 		}
+	case "esxi":
+		config.ESXi = new(ConfigStorageESXi)
+		config.ESXi.Server = rawConfig["server"].(string)
+		if _, isSet := rawConfig["username"]; isSet {
+			config.ESXi.Username = rawConfig["username"].(string)
+		}
+		if _, isSet := rawConfig["skip-cert-verification"]; isSet {
+			config.ESXi.SkipCertVerification = apiBool(rawConfig["skip-cert-verification"])
+		}
 	}
 	config.SetDefaults()
-	if _, isSet := rawConfig["content"]; isSet {
+	if _, isSet := rawConfig["content"]; isSet && config.Type != "esxi" {
 		content := rawConfig["content"].(string)
 		if content != "none" {
 			contentArray := CSVtoArray(content)
@@ -1180,21 +1261,7 @@ func NewConfigStorageFromApi(storageid string, client *Client) (config *ConfigSt
 		}
 	}
 	if _, isSet := rawConfig["prune-backups"]; isSet {
-		prune := CSVtoArray(rawConfig["prune-backups"].(string))
-		if !inArray(prune, "keep-all=1") {
-			retentionSettings := make(map[string]int)
-			for _, e := range prune {
-				a := strings.Split(e, "=")
-				retentionSettings[a[0]], _ = strconv.Atoi(a[1])
-			}
-			config.BackupRetention = new(ConfigStorageBackupRetention)
-			config.BackupRetention.Daily = util.Pointer(retentionSettings["keep-daily"])
-			config.BackupRetention.Hourly = util.Pointer(retentionSettings["keep-hourly"])
-			config.BackupRetention.Last = util.Pointer(retentionSettings["keep-last"])
-			config.BackupRetention.Monthly = util.Pointer(retentionSettings["keep-monthly"])
-			config.BackupRetention.Weekly = util.Pointer(retentionSettings["keep-weekly"])
-			config.BackupRetention.Yearly = util.Pointer(retentionSettings["keep-yearly"])
-		}
+		config.BackupRetention = ConfigStorageBackupRetention{}.mapToSDK(CSVtoArray(rawConfig["prune-backups"].(string)))
 	}
 	return
 }
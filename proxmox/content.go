@@ -12,12 +12,14 @@ const (
 	ContentType_Backup             ContentType = "backup"
 	ContentType_Container          ContentType = "container"
 	ContentType_DiskImage          ContentType = "diskimage"
+	ContentType_Import             ContentType = "import"
 	ContentType_Iso                ContentType = "iso"
 	ContentType_Snippets           ContentType = "snippets"
 	ContentType_Template           ContentType = "template"
 	contentType_Backup_ApiValue    ContentType = "backup"
 	contentType_Container_ApiValue ContentType = "rootdir"
 	contentType_DiskImage_ApiValue ContentType = "images"
+	contentType_Import_ApiValue    ContentType = "import"
 	contentType_Snippets_ApiValue  ContentType = "snippets"
 	contentType_Iso_ApiValue       ContentType = "iso"
 	contentType_Template_ApiValue  ContentType = "vztmpl"
@@ -32,6 +34,8 @@ func (c ContentType) toApiValue() ContentType {
 		return contentType_Container_ApiValue
 	case ContentType_DiskImage, contentType_DiskImage_ApiValue:
 		return contentType_DiskImage_ApiValue
+	case ContentType_Import:
+		return contentType_Import_ApiValue
 	case ContentType_Iso:
 		return contentType_Iso_ApiValue
 	case ContentType_Snippets:
@@ -42,6 +46,27 @@ func (c ContentType) toApiValue() ContentType {
 	return ""
 }
 
+// Converts the value of the proxmox api to the user friendly enum value.
+func (ContentType) fromApiValue(api string) ContentType {
+	switch ContentType(api) {
+	case contentType_Backup_ApiValue:
+		return ContentType_Backup
+	case contentType_Container_ApiValue:
+		return ContentType_Container
+	case contentType_DiskImage_ApiValue:
+		return ContentType_DiskImage
+	case contentType_Import_ApiValue:
+		return ContentType_Import
+	case contentType_Iso_ApiValue:
+		return ContentType_Iso
+	case contentType_Snippets_ApiValue:
+		return ContentType_Snippets
+	case contentType_Template_ApiValue:
+		return ContentType_Template
+	}
+	return ContentType(api)
+}
+
 // Converts the user friendly enum value to a value the proxmox api understands.
 // If the input enum value is invalid it will return an error.
 func (c ContentType) toApiValueAndValidate() (api ContentType, err error) {
@@ -54,7 +79,7 @@ func (c ContentType) toApiValueAndValidate() (api ContentType, err error) {
 
 // Returns a list of all enum options.
 func (c ContentType) enumList() string {
-	return string(ContentType_Backup) + "," + string(ContentType_Container) + "," + string(ContentType_DiskImage) + "," + string(ContentType_Iso) + "," + string(ContentType_Snippets) + "," + string(ContentType_Template)
+	return string(ContentType_Backup) + "," + string(ContentType_Container) + "," + string(ContentType_DiskImage) + "," + string(ContentType_Import) + "," + string(ContentType_Iso) + "," + string(ContentType_Snippets) + "," + string(ContentType_Template)
 }
 
 // Returns an error if the enum value is invalid.
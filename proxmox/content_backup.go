@@ -0,0 +1,213 @@
+package proxmox
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// A backup volume on a storage.
+type Content_Backup struct {
+	CreationTime time.Time                   `json:"time"`
+	Format       string                      `json:"format"`
+	GuestID      uint                        `json:"guest_id"`
+	GuestType    GuestType                   `json:"guest_type"`
+	Notes        string                      `json:"notes,omitempty"`
+	Protected    bool                        `json:"protected"`
+	Size         uint                        `json:"size"`
+	Verification *Content_BackupVerification `json:"verification,omitempty"` // only set for backups on a Proxmox Backup Server that have been verified
+	VolumeId     string                      `json:"volume_id"`
+}
+
+type Content_BackupVerification struct {
+	State string `json:"state"` // e.g. "ok" or "failed"
+	UpID  string `json:"upid"`  // id of the verification task
+}
+
+// Returns nil when the value is not a verification.
+func (Content_BackupVerification) mapToSDK(value interface{}) *Content_BackupVerification {
+	params, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	verification := Content_BackupVerification{}
+	if v, isSet := params["state"]; isSet {
+		verification.State = v.(string)
+	}
+	if v, isSet := params["upid"]; isSet {
+		verification.UpID = v.(string)
+	}
+	return &verification
+}
+
+// The settings shared with other volumes are mapped by Content_Volume.
+func (Content_Backup) mapToSDK(params map[string]interface{}) Content_Backup {
+	volume := Content_Volume{}.mapToSDK(params)
+	backup := Content_Backup{
+		Format:       volume.Format,
+		GuestID:      volume.GuestID,
+		Notes:        volume.Notes,
+		Protected:    volume.Protected,
+		Size:         volume.SizeBytes,
+		Verification: volume.Verification,
+		VolumeId:     volume.VolumeId}
+	if volume.CreationTime != nil {
+		backup.CreationTime = *volume.CreationTime
+	}
+	if v, isSet := params["subtype"]; isSet {
+		backup.GuestType = GuestType(v.(string))
+	}
+	return backup
+}
+
+// List all backups on the storage, when guestID is 0 the backups of all guests are returned.
+// The backups are sorted by creation time, oldest first.
+func ListBackups(client *Client, node, storage string, guestID uint) ([]Content_Backup, error) {
+	if client == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	query := "?content=" + string(contentType_Backup_ApiValue)
+	if guestID != 0 {
+		query += "&vmid=" + strconv.FormatUint(uint64(guestID), 10)
+	}
+	list, err := client.GetItemListInterfaceArray("/nodes/" + node + "/storage/" + storage + "/content" + query)
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]Content_Backup, len(list))
+	for i, e := range list {
+		backups[i] = Content_Backup{}.mapToSDK(e.(map[string]interface{}))
+	}
+	sort.SliceStable(backups, func(i, j int) bool { return backups[i].CreationTime.Before(backups[j].CreationTime) })
+	return backups, nil
+}
+
+type BackupPruneMark string
+
+const (
+	BackupPruneMark_Keep      BackupPruneMark = "keep"
+	BackupPruneMark_Protected BackupPruneMark = "protected"
+	BackupPruneMark_Remove    BackupPruneMark = "remove"
+)
+
+type BackupPruneResult struct {
+	Backup Content_Backup  `json:"backup"`
+	Mark   BackupPruneMark `json:"mark"`
+}
+
+// Simulates pruning the backups with the retention, the same way Proxmox does.
+// The backups of every guest are pruned separately, protected backups are never removed.
+// When retention is nil all backups are kept.
+// The result is sorted by creation time, newest first.
+func SimulateBackupPrune(backups []Content_Backup, retention *ConfigStorageBackupRetention) []BackupPruneResult {
+	results := make([]BackupPruneResult, len(backups))
+	for i, e := range backups {
+		results[i] = BackupPruneResult{Backup: e}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Backup.CreationTime.After(results[j].Backup.CreationTime) })
+	groups := make(map[string][]*BackupPruneResult)
+	for i := range results {
+		if results[i].Backup.Protected {
+			results[i].Mark = BackupPruneMark_Protected
+			continue
+		}
+		if retention == nil || retention.AllNil() {
+			results[i].Mark = BackupPruneMark_Keep
+			continue
+		}
+		key := string(results[i].Backup.GuestType) + "/" + strconv.FormatUint(uint64(results[i].Backup.GuestID), 10)
+		groups[key] = append(groups[key], &results[i])
+	}
+	for _, group := range groups {
+		// keep-last counts every backup, the creation time only has a resolution of seconds
+		backupPruneMark(group, retention.Last, func(b Content_Backup) string { return b.VolumeId })
+		backupPruneMark(group, retention.Hourly, func(b Content_Backup) string { return b.CreationTime.Format("2006/01/02/15") })
+		backupPruneMark(group, retention.Daily, func(b Content_Backup) string { return b.CreationTime.Format("2006/01/02") })
+		backupPruneMark(group, retention.Weekly, func(b Content_Backup) string {
+			year, week := b.CreationTime.ISOWeek()
+			return strconv.Itoa(year) + "/" + strconv.Itoa(week)
+		})
+		backupPruneMark(group, retention.Monthly, func(b Content_Backup) string { return b.CreationTime.Format("2006/01") })
+		backupPruneMark(group, retention.Yearly, func(b Content_Backup) string { return b.CreationTime.Format("2006") })
+		for _, e := range group {
+			if e.Mark == "" {
+				e.Mark = BackupPruneMark_Remove
+			}
+		}
+	}
+	return results
+}
+
+// Keeps the newest backup of each period, for at most keep periods.
+// Periods that already have a kept backup are skipped and do not count towards keep.
+// The group should be sorted newest first.
+func backupPruneMark(group []*BackupPruneResult, keep *int, period func(Content_Backup) string) {
+	if keep == nil || *keep <= 0 {
+		return
+	}
+	alreadyIncluded := make(map[string]struct{})
+	for _, e := range group {
+		if e.Mark == BackupPruneMark_Keep {
+			alreadyIncluded[period(e.Backup)] = struct{}{}
+		}
+	}
+	newlyIncluded := make(map[string]struct{})
+	for _, e := range group {
+		id := period(e.Backup)
+		if e.Mark != "" {
+			continue
+		}
+		if _, isSet := alreadyIncluded[id]; isSet {
+			continue
+		}
+		if _, isSet := newlyIncluded[id]; isSet {
+			e.Mark = BackupPruneMark_Remove
+			continue
+		}
+		if len(newlyIncluded) >= *keep {
+			break
+		}
+		newlyIncluded[id] = struct{}{}
+		e.Mark = BackupPruneMark_Keep
+	}
+}
+
+// Lists the backups on the storage and simulates pruning them with the retention, see SimulateBackupPrune.
+// When guestID is 0 the backups of all guests are included.
+func SimulatePruneBackups(client *Client, node, storage string, guestID uint, retention *ConfigStorageBackupRetention) ([]BackupPruneResult, error) {
+	if err := retention.Validate(); err != nil {
+		return nil, err
+	}
+	backups, err := ListBackups(client, node, storage, guestID)
+	if err != nil {
+		return nil, err
+	}
+	return SimulateBackupPrune(backups, retention), nil
+}
+
+// Prunes the backups on the storage with the retention, when retention is nil the retention configured on the storage is used.
+// When guestID is 0 the backups of all guests are pruned.
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/storage/{storage}/prunebackups
+func PruneBackups(client *Client, node, storage string, guestID uint, retention *ConfigStorageBackupRetention) error {
+	if client == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	if err := retention.Validate(); err != nil {
+		return err
+	}
+	params := url.Values{}
+	if retention != nil {
+		params.Set("prune-backups", retention.MapStorageBackupRetention())
+	}
+	if guestID != 0 {
+		params.Set("vmid", strconv.FormatUint(uint64(guestID), 10))
+	}
+	query := ""
+	if len(params) > 0 {
+		query = "?" + params.Encode()
+	}
+	_, err := client.DeleteWithTask("/nodes/" + node + "/storage/" + storage + "/prunebackups" + query)
+	return err
+}
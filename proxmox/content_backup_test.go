@@ -0,0 +1,164 @@
+package proxmox
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Content_Backup_mapToSDK(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output Content_Backup
+	}{
+		{name: `Directory storage`,
+			input: map[string]interface{}{
+				"content": "backup",
+				"ctime":   float64(1714557600),
+				"format":  "vma.zst",
+				"notes":   "before upgrade",
+				"size":    float64(1073741824),
+				"subtype": "qemu",
+				"vmid":    float64(100),
+				"volid":   "local:backup/vzdump-qemu-100-2024_05_01-10_00_00.vma.zst"},
+			output: Content_Backup{
+				CreationTime: time.Unix(1714557600, 0),
+				Format:       "vma.zst",
+				GuestID:      100,
+				GuestType:    GuestQemu,
+				Notes:        "before upgrade",
+				Size:         1073741824,
+				VolumeId:     "local:backup/vzdump-qemu-100-2024_05_01-10_00_00.vma.zst"}},
+		{name: `Proxmox Backup Server`,
+			input: map[string]interface{}{
+				"ctime":        float64(1714557600),
+				"format":       "pbs-ct",
+				"protected":    float64(1),
+				"subtype":      "lxc",
+				"verification": map[string]interface{}{"state": "ok", "upid": "UPID:pbs:0000:0000:0000:verify::root@pam:"},
+				"vmid":         float64(101),
+				"volid":        "pbs:backup/ct/101/2024-05-01T10:00:00Z"},
+			output: Content_Backup{
+				CreationTime: time.Unix(1714557600, 0),
+				Format:       "pbs-ct",
+				GuestID:      101,
+				GuestType:    GuestLXC,
+				Protected:    true,
+				Verification: &Content_BackupVerification{State: "ok", UpID: "UPID:pbs:0000:0000:0000:verify::root@pam:"},
+				VolumeId:     "pbs:backup/ct/101/2024-05-01T10:00:00Z"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, Content_Backup{}.mapToSDK(test.input))
+		})
+	}
+}
+
+func Test_Content_BackupVerification_mapToSDK(t *testing.T) {
+	require.Equal(t,
+		&Content_BackupVerification{State: "failed", UpID: "UPID:pbs:0000:0000:0000:verify::root@pam:"},
+		Content_BackupVerification{}.mapToSDK(map[string]interface{}{"state": "failed", "upid": "UPID:pbs:0000:0000:0000:verify::root@pam:"}))
+	require.Equal(t, &Content_BackupVerification{}, Content_BackupVerification{}.mapToSDK(map[string]interface{}{}))
+	require.Nil(t, Content_BackupVerification{}.mapToSDK(nil))
+	require.Nil(t, Content_BackupVerification{}.mapToSDK("ok"))
+}
+
+func Test_SimulateBackupPrune(t *testing.T) {
+	date := func(day, hour int) time.Time { return time.Date(2024, 5, day, hour, 0, 0, 0, time.UTC) }
+	backup := func(id uint, day, hour int) Content_Backup {
+		return Content_Backup{GuestID: id, GuestType: GuestQemu, CreationTime: date(day, hour),
+			VolumeId: date(day, hour).Format("local:backup/vzdump-qemu-" + strconv.Itoa(int(id)) + "-2006_01_02-15_04_05.vma.zst")}
+	}
+	format := func(b Content_Backup, format string) Content_Backup {
+		b.Format = format
+		b.VolumeId = strings.TrimSuffix(b.VolumeId, ".vma.zst") + "." + format
+		return b
+	}
+	protected := func(b Content_Backup) Content_Backup { b.Protected = true; return b }
+	type testOutput struct {
+		id   uint
+		time time.Time
+		mark BackupPruneMark
+	}
+	tests := []struct {
+		name      string
+		backups   []Content_Backup
+		retention *ConfigStorageBackupRetention
+		output    []testOutput
+	}{
+		{name: `nil retention keeps all`,
+			backups: []Content_Backup{backup(100, 1, 0), backup(100, 2, 0)},
+			output: []testOutput{
+				{100, date(2, 0), BackupPruneMark_Keep},
+				{100, date(1, 0), BackupPruneMark_Keep}}},
+		{name: `keep-last`,
+			backups:   []Content_Backup{backup(100, 1, 0), backup(100, 3, 0), backup(100, 2, 0)},
+			retention: &ConfigStorageBackupRetention{Last: util.Pointer(2)},
+			output: []testOutput{
+				{100, date(3, 0), BackupPruneMark_Keep},
+				{100, date(2, 0), BackupPruneMark_Keep},
+				{100, date(1, 0), BackupPruneMark_Remove}}},
+		{name: `keep-last backups with the same creation time`,
+			backups:   []Content_Backup{backup(100, 1, 0), backup(100, 2, 0), format(backup(100, 2, 0), "vma.gz")},
+			retention: &ConfigStorageBackupRetention{Last: util.Pointer(2)},
+			output: []testOutput{
+				{100, date(2, 0), BackupPruneMark_Keep},
+				{100, date(2, 0), BackupPruneMark_Keep},
+				{100, date(1, 0), BackupPruneMark_Remove}}},
+		{name: `keep-daily keeps newest of each day`,
+			backups:   []Content_Backup{backup(100, 1, 1), backup(100, 1, 2), backup(100, 2, 1), backup(100, 2, 2), backup(100, 3, 1)},
+			retention: &ConfigStorageBackupRetention{Daily: util.Pointer(2)},
+			output: []testOutput{
+				{100, date(3, 1), BackupPruneMark_Keep},
+				{100, date(2, 2), BackupPruneMark_Keep},
+				{100, date(2, 1), BackupPruneMark_Remove},
+				{100, date(1, 2), BackupPruneMark_Remove},
+				{100, date(1, 1), BackupPruneMark_Remove}}},
+		{name: `keep-last and keep-daily`,
+			backups:   []Content_Backup{backup(100, 1, 1), backup(100, 2, 1), backup(100, 3, 1), backup(100, 3, 2)},
+			retention: &ConfigStorageBackupRetention{Last: util.Pointer(1), Daily: util.Pointer(2)},
+			output: []testOutput{
+				{100, date(3, 2), BackupPruneMark_Keep},   // keep-last
+				{100, date(3, 1), BackupPruneMark_Remove}, // day already covered by keep-last
+				{100, date(2, 1), BackupPruneMark_Keep},   // keep-daily
+				{100, date(1, 1), BackupPruneMark_Keep}}}, // keep-daily
+		{name: `protected backups are not counted`,
+			backups:   []Content_Backup{backup(100, 1, 0), protected(backup(100, 3, 0)), backup(100, 2, 0)},
+			retention: &ConfigStorageBackupRetention{Last: util.Pointer(1)},
+			output: []testOutput{
+				{100, date(3, 0), BackupPruneMark_Protected},
+				{100, date(2, 0), BackupPruneMark_Keep},
+				{100, date(1, 0), BackupPruneMark_Remove}}},
+		{name: `guests are pruned separately`,
+			backups:   []Content_Backup{backup(100, 1, 0), backup(101, 2, 0), backup(100, 3, 0), backup(101, 4, 0)},
+			retention: &ConfigStorageBackupRetention{Last: util.Pointer(1)},
+			output: []testOutput{
+				{101, date(4, 0), BackupPruneMark_Keep},
+				{100, date(3, 0), BackupPruneMark_Keep},
+				{101, date(2, 0), BackupPruneMark_Remove},
+				{100, date(1, 0), BackupPruneMark_Remove}}},
+		{name: `keep-weekly keeps newest of each week`,
+			backups:   []Content_Backup{backup(100, 6, 0), backup(100, 7, 0), backup(100, 13, 0), backup(100, 20, 0)},
+			retention: &ConfigStorageBackupRetention{Weekly: util.Pointer(2)},
+			output: []testOutput{
+				{100, date(20, 0), BackupPruneMark_Keep},
+				{100, date(13, 0), BackupPruneMark_Keep},
+				{100, date(7, 0), BackupPruneMark_Remove},
+				{100, date(6, 0), BackupPruneMark_Remove}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := SimulateBackupPrune(test.backups, test.retention)
+			output := make([]testOutput, len(results))
+			for i, e := range results {
+				output[i] = testOutput{e.Backup.GuestID, e.Backup.CreationTime, e.Mark}
+			}
+			require.Equal(t, test.output, output)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package proxmox
+
+import (
+	"errors"
+)
+
+// Disk image to download to the import content of a storage.
+type ConfigContent_Import struct {
+	Checksum          string
+	ChecksumAlgorithm string
+	DownloadUrl       string
+	Filename          string // should end in .qcow2, .raw or .vmdk
+	Node              string
+	Storage           string
+}
+
+func (content ConfigContent_Import) error(text string) error {
+	return errors.New("the value of (" + text + ") may not be empty")
+}
+
+func (content ConfigContent_Import) mapToApiValues() map[string]interface{} {
+	return map[string]interface{}{
+		"checksum-algorithm": content.ChecksumAlgorithm,
+		"checksum":           content.Checksum,
+		"content":            string(contentType_Import_ApiValue),
+		"filename":           content.Filename,
+		"storage":            content.Storage,
+		"url":                content.DownloadUrl,
+	}
+}
+
+// Returns the import source of the downloaded disk image.
+func (content ConfigContent_Import) ImportFrom() *QemuDiskImport {
+	return &QemuDiskImport{Volume: content.Storage + ":" + string(contentType_Import_ApiValue) + "/" + content.Filename}
+}
+
+// Return an error if the one of the values is empty, or when the filename is not a disk format that can be imported.
+func (content ConfigContent_Import) Validate() (err error) {
+	if content.Node == "" {
+		return content.error("Node")
+	}
+	if content.Storage == "" {
+		return content.error("Storage")
+	}
+	if content.DownloadUrl == "" {
+		return content.error("URL")
+	}
+	if content.Filename == "" {
+		return content.error("Filename")
+	}
+	return qemuDiskImportFormat(content.Filename)
+}
+
+// Download a disk image from a given URL to the import content of a storage.
+// The returned import source can be used as ImportFrom of a new disk.
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/storage/{storage}/download-url
+func DownloadImportFromUrl(client *Client, content ConfigContent_Import) (*QemuDiskImport, error) {
+	if err := content.Validate(); err != nil {
+		return nil, err
+	}
+	_, err := client.PostWithTask(content.mapToApiValues(), "/nodes/"+content.Node+"/storage/"+content.Storage+"/download-url")
+	if err != nil {
+		return nil, err
+	}
+	return content.ImportFrom(), nil
+}
@@ -0,0 +1,59 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigContent_Import_ImportFrom(t *testing.T) {
+	require.Equal(t, &QemuDiskImport{Volume: "local:import/debian-12.qcow2"}, ConfigContent_Import{Filename: "debian-12.qcow2", Storage: "local"}.ImportFrom())
+}
+
+func Test_ConfigContent_Import_mapToApiValues(t *testing.T) {
+	require.Equal(t, map[string]interface{}{
+		"checksum-algorithm": "sha512",
+		"checksum":           "xxx",
+		"content":            "import",
+		"filename":           "debian-12.qcow2",
+		"storage":            "local",
+		"url":                "https://example.com/debian-12.qcow2",
+	}, ConfigContent_Import{
+		Checksum:          "xxx",
+		ChecksumAlgorithm: "sha512",
+		DownloadUrl:       "https://example.com/debian-12.qcow2",
+		Filename:          "debian-12.qcow2",
+		Storage:           "local",
+	}.mapToApiValues())
+}
+
+func Test_ConfigContent_Import_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  ConfigContent_Import
+		output error
+	}{
+		{name: `Valid`,
+			input: ConfigContent_Import{Node: "pve", Storage: "local", DownloadUrl: "https://example.com/debian-12.qcow2", Filename: "debian-12.qcow2"}},
+		{name: `Invalid Node`,
+			output: ConfigContent_Import{}.error("Node")},
+		{name: `Invalid Storage`,
+			input:  ConfigContent_Import{Node: "pve"},
+			output: ConfigContent_Import{}.error("Storage")},
+		{name: `Invalid DownloadUrl`,
+			input:  ConfigContent_Import{Node: "pve", Storage: "local"},
+			output: ConfigContent_Import{}.error("URL")},
+		{name: `Invalid Filename`,
+			input:  ConfigContent_Import{Node: "pve", Storage: "local", DownloadUrl: "https://example.com/debian-12.qcow2"},
+			output: ConfigContent_Import{}.error("Filename")},
+		{name: `Invalid Filename format`,
+			input:  ConfigContent_Import{Node: "pve", Storage: "local", DownloadUrl: "https://example.com/debian-12.iso", Filename: "debian-12.iso"},
+			output: errors.New(Error_QemuDiskImport_Format)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
@@ -48,6 +48,7 @@ func test_Content_ContentType_Input() []ContentType {
 		ContentType_Backup,
 		ContentType_Container,
 		ContentType_DiskImage,
+		ContentType_Import,
 		ContentType_Iso,
 		ContentType_Snippets,
 		ContentType_Template,
@@ -55,6 +56,7 @@ func test_Content_ContentType_Input() []ContentType {
 		contentType_Backup_ApiValue,
 		contentType_Container_ApiValue,
 		contentType_DiskImage_ApiValue,
+		contentType_Import_ApiValue,
 		contentType_Iso_ApiValue,
 		contentType_Snippets_ApiValue,
 		contentType_Template_ApiValue,
@@ -78,6 +80,10 @@ func test_Content_ContentType_Output() []test_Content_ContentType {
 			ApiValue: contentType_DiskImage_ApiValue,
 			err:      nil,
 		},
+		{
+			ApiValue: contentType_Import_ApiValue,
+			err:      nil,
+		},
 		{
 			ApiValue: contentType_Iso_ApiValue,
 			err:      nil,
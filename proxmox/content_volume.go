@@ -0,0 +1,149 @@
+package proxmox
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// A volume on a storage, e.g. a disk image, iso or backup.
+type Content_Volume struct {
+	Content      ContentType                 `json:"content"`
+	CreationTime *time.Time                  `json:"time,omitempty"`
+	Encrypted    bool                        `json:"encrypted"`
+	Format       string                      `json:"format"`
+	GuestID      uint                        `json:"guest_id,omitempty"` // the guest that owns the volume, 0 when it is not owned by a guest
+	Notes        string                      `json:"notes,omitempty"`
+	Parent       string                      `json:"parent,omitempty"` // the base volume of a linked clone
+	Protected    bool                        `json:"protected"`
+	SizeBytes    uint                        `json:"size"`
+	UsedBytes    uint                        `json:"used,omitempty"` // only reported by some storages
+	Verification *Content_BackupVerification `json:"verification,omitempty"`
+	VolumeId     string                      `json:"volume_id"`
+}
+
+func (Content_Volume) mapToSDK(params map[string]interface{}) (volume Content_Volume) {
+	if v, isSet := params["content"]; isSet {
+		volume.Content = ContentType("").fromApiValue(v.(string))
+	}
+	if v, isSet := params["ctime"]; isSet {
+		creationTime := time.Unix(int64(v.(float64)), 0)
+		volume.CreationTime = &creationTime
+	}
+	if v, isSet := params["encrypted"]; isSet {
+		// a Proxmox Backup Server reports the fingerprint of the key, or "1" when the fingerprint is unknown
+		switch encrypted := v.(type) {
+		case string:
+			volume.Encrypted = encrypted != "" && encrypted != "0"
+		default:
+			volume.Encrypted = apiBool(v)
+		}
+	}
+	if v, isSet := params["format"]; isSet {
+		volume.Format = v.(string)
+	}
+	if v, isSet := params["notes"]; isSet {
+		volume.Notes = v.(string)
+	}
+	if v, isSet := params["parent"]; isSet {
+		volume.Parent = v.(string)
+	}
+	if v, isSet := params["protected"]; isSet {
+		volume.Protected = apiBool(v)
+	}
+	if v, isSet := params["size"]; isSet {
+		volume.SizeBytes = uint(v.(float64))
+	}
+	if v, isSet := params["used"]; isSet {
+		volume.UsedBytes = uint(v.(float64))
+	}
+	if v, isSet := params["verification"]; isSet {
+		volume.Verification = Content_BackupVerification{}.mapToSDK(v)
+	}
+	if v, isSet := params["vmid"]; isSet {
+		volume.GuestID = uint(v.(float64))
+	}
+	if v, isSet := params["volid"]; isSet {
+		volume.VolumeId = v.(string)
+	}
+	return
+}
+
+// Limits the volumes that are listed, the zero value lists all volumes.
+type Content_VolumeFilter struct {
+	Content ContentType `json:"content,omitempty"`
+	GuestID uint        `json:"guest_id,omitempty"`
+}
+
+func (filter Content_VolumeFilter) mapToApiValues() (url.Values, error) {
+	params := url.Values{}
+	if filter.Content != "" {
+		content, err := filter.Content.toApiValueAndValidate()
+		if err != nil {
+			return nil, err
+		}
+		params.Set("content", string(content))
+	}
+	if filter.GuestID != 0 {
+		params.Set("vmid", strconv.FormatUint(uint64(filter.GuestID), 10))
+	}
+	return params, nil
+}
+
+// List the volumes on the storage that match the filter, sorted by volume id.
+func ListVolumes(client *Client, node, storage string, filter Content_VolumeFilter) ([]Content_Volume, error) {
+	if client == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	params, err := filter.mapToApiValues()
+	if err != nil {
+		return nil, err
+	}
+	query := ""
+	if len(params) > 0 {
+		query = "?" + params.Encode()
+	}
+	list, err := client.GetItemListInterfaceArray("/nodes/" + node + "/storage/" + storage + "/content" + query)
+	if err != nil {
+		return nil, err
+	}
+	volumes := make([]Content_Volume, len(list))
+	for i, e := range list {
+		volumes[i] = Content_Volume{}.mapToSDK(e.(map[string]interface{}))
+	}
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i].VolumeId < volumes[j].VolumeId })
+	return volumes, nil
+}
+
+// List the volumes on the storage that match the filter and are owned by a guest that no longer exists.
+// Note that backups are also owned by a guest, use the filter to e.g. only include disk images.
+func ListOrphanedVolumes(client *Client, node, storage string, filter Content_VolumeFilter) ([]Content_Volume, error) {
+	volumes, err := ListVolumes(client, node, storage, filter)
+	if err != nil {
+		return nil, err
+	}
+	guests, err := ListGuests(client)
+	if err != nil {
+		return nil, err
+	}
+	return orphanedVolumes(volumes, guests), nil
+}
+
+func orphanedVolumes(volumes []Content_Volume, guests []GuestResource) []Content_Volume {
+	existing := make(map[uint]struct{}, len(guests))
+	for _, e := range guests {
+		existing[e.Id] = struct{}{}
+	}
+	orphans := make([]Content_Volume, 0)
+	for _, e := range volumes {
+		if e.GuestID == 0 {
+			continue
+		}
+		if _, isSet := existing[e.GuestID]; !isSet {
+			orphans = append(orphans, e)
+		}
+	}
+	return orphans
+}
@@ -0,0 +1,203 @@
+package proxmox
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Disk image volume to allocate on a storage, independent of a guest config.
+type ConfigContent_Volume struct {
+	Filename        string         // e.g. "vm-100-disk-5" or "vm-100-disk-5.qcow2", the id in the name should match GuestID
+	Format          QemuDiskFormat // when empty the default format of the storage is used
+	GuestID         uint           // the guest that owns the volume
+	Node            string
+	SizeInKibibytes QemuDiskSize
+	Storage         string
+}
+
+const (
+	ConfigContent_Volume_Error_Filename string = "filename should be (vm|base)-<guest id>-<name>, and the guest id should match"
+	ConfigContent_Volume_Error_GuestID  string = "guest id should be specified"
+)
+
+var regex_VolumeFilename = regexp.MustCompile(`^(vm|base)-([1-9][0-9]*)-[a-zA-Z0-9\-_.]+$`)
+
+func (content ConfigContent_Volume) error(text string) error {
+	return errors.New("the value of (" + text + ") may not be empty")
+}
+
+func (content ConfigContent_Volume) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{
+		"filename": content.Filename,
+		"size":     strconv.FormatUint(uint64(content.SizeInKibibytes), 10) + "K",
+		"vmid":     int(content.GuestID),
+	}
+	if content.Format != "" {
+		params["format"] = string(content.Format)
+	}
+	return params
+}
+
+func (content ConfigContent_Volume) Validate() error {
+	if content.Node == "" {
+		return content.error("Node")
+	}
+	if content.Storage == "" {
+		return content.error("Storage")
+	}
+	if content.GuestID == 0 {
+		return errors.New(ConfigContent_Volume_Error_GuestID)
+	}
+	match := regex_VolumeFilename.FindStringSubmatch(content.Filename)
+	if match == nil || match[2] != strconv.FormatUint(uint64(content.GuestID), 10) {
+		return errors.New(ConfigContent_Volume_Error_Filename)
+	}
+	if content.Format != "" {
+		if err := content.Format.Validate(); err != nil {
+			return err
+		}
+	}
+	return content.SizeInKibibytes.Validate()
+}
+
+// Allocates a new disk image volume on the storage, returns the volume id of the new volume.
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/storage/{storage}/content
+func AllocateVolume(client *Client, content ConfigContent_Volume) (string, error) {
+	if client == nil {
+		return "", errors.New(Client_Error_Nil)
+	}
+	if err := content.Validate(); err != nil {
+		return "", err
+	}
+	reqbody := ParamsToBody(content.mapToApiValues())
+	resp, err := client.session.Post("/nodes/"+content.Node+"/storage/"+content.Storage+"/content", nil, nil, &reqbody)
+	if err != nil {
+		return "", err
+	}
+	data, err := ResponseJSON(resp)
+	if err != nil {
+		return "", err
+	}
+	volumeId, _ := data["data"].(string)
+	return volumeId, nil
+}
+
+// Copy of a volume to another storage, node or guest.
+type ConfigContent_VolumeCopy struct {
+	Node           string // node the source volume is on
+	SourceVolumeId string // e.g. "local-lvm:vm-100-disk-0"
+	TargetFilename string // e.g. "vm-200-disk-0", to copy the volume to another guest use the id of that guest
+	TargetNode     string // when empty the volume is copied on Node
+	TargetStorage  string
+}
+
+func (content ConfigContent_VolumeCopy) error(text string) error {
+	return errors.New("the value of (" + text + ") may not be empty")
+}
+
+func (content ConfigContent_VolumeCopy) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{"target": content.TargetVolumeId()}
+	if content.TargetNode != "" {
+		params["target_node"] = content.TargetNode
+	}
+	return params
+}
+
+// Returns the volume id of the copy, e.g. "local-lvm:vm-200-disk-0".
+func (content ConfigContent_VolumeCopy) TargetVolumeId() string {
+	return content.TargetStorage + ":" + content.TargetFilename
+}
+
+func (content ConfigContent_VolumeCopy) Validate() error {
+	if content.Node == "" {
+		return content.error("Node")
+	}
+	if content.SourceVolumeId == "" {
+		return content.error("SourceVolumeId")
+	}
+	if content.TargetStorage == "" {
+		return content.error("TargetStorage")
+	}
+	if content.TargetFilename == "" {
+		return content.error("TargetFilename")
+	}
+	return nil
+}
+
+// Copies the volume, returns the volume id of the copy.
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/storage/{storage}/content/{volume}
+func CopyVolume(client *Client, content ConfigContent_VolumeCopy) (string, error) {
+	if client == nil {
+		return "", errors.New(Client_Error_Nil)
+	}
+	if err := content.Validate(); err != nil {
+		return "", err
+	}
+	if _, err := client.PostWithTask(content.mapToApiValues(), volumeUrl(content.Node, content.SourceVolumeId)); err != nil {
+		return "", err
+	}
+	return content.TargetVolumeId(), nil
+}
+
+// Attributes of a volume, when updating only the attributes that are not nil are changed.
+type Content_VolumeAttributes struct {
+	Notes     *string `json:"notes,omitempty"`
+	Protected *bool   `json:"protected,omitempty"` // protected volumes can not be removed, only supported for backups
+}
+
+func (attributes Content_VolumeAttributes) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{}
+	if attributes.Notes != nil {
+		params["notes"] = *attributes.Notes
+	}
+	if attributes.Protected != nil {
+		params["protected"] = *attributes.Protected
+	}
+	return params
+}
+
+func (Content_VolumeAttributes) mapToSDK(params map[string]interface{}) Content_VolumeAttributes {
+	notes := ""
+	protected := false
+	if v, isSet := params["notes"]; isSet {
+		notes = v.(string)
+	}
+	if v, isSet := params["protected"]; isSet {
+		protected = apiBool(v)
+	}
+	return Content_VolumeAttributes{Notes: &notes, Protected: &protected}
+}
+
+// Reads the notes and protected flag of the volume.
+func GetVolumeAttributes(client *Client, node, volumeId string) (*Content_VolumeAttributes, error) {
+	if client == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	params, err := client.GetItemConfigMapStringInterface(volumeUrl(node, volumeId), "volume", "ATTRIBUTES")
+	if err != nil {
+		return nil, err
+	}
+	attributes := Content_VolumeAttributes{}.mapToSDK(params)
+	return &attributes, nil
+}
+
+// Updates the notes and protected flag of the volume.
+func SetVolumeAttributes(client *Client, node, volumeId string, attributes Content_VolumeAttributes) error {
+	if client == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	params := attributes.mapToApiValues()
+	if len(params) == 0 {
+		return nil
+	}
+	return client.Put(params, volumeUrl(node, volumeId))
+}
+
+// Returns the api url of the volume, the volume id should include the storage, e.g. "local-lvm:vm-100-disk-0".
+func volumeUrl(node, volumeId string) string {
+	storage, _, _ := strings.Cut(volumeId, ":")
+	return "/nodes/" + node + "/storage/" + storage + "/content/" + url.PathEscape(volumeId)
+}
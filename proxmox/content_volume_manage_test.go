@@ -0,0 +1,102 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigContent_Volume_mapToApiValues(t *testing.T) {
+	require.Equal(t,
+		map[string]interface{}{
+			"filename": "vm-100-disk-5.qcow2",
+			"format":   "qcow2",
+			"size":     "10485760K",
+			"vmid":     100},
+		ConfigContent_Volume{
+			Filename:        "vm-100-disk-5.qcow2",
+			Format:          QemuDiskFormat_Qcow2,
+			GuestID:         100,
+			SizeInKibibytes: 10 * gibibyte}.mapToApiValues())
+	require.Equal(t,
+		map[string]interface{}{
+			"filename": "base-9000-disk-0",
+			"size":     "8192K",
+			"vmid":     9000},
+		ConfigContent_Volume{
+			Filename:        "base-9000-disk-0",
+			GuestID:         9000,
+			SizeInKibibytes: 8192}.mapToApiValues())
+}
+
+func Test_ConfigContent_Volume_Validate(t *testing.T) {
+	valid := func() ConfigContent_Volume {
+		return ConfigContent_Volume{Filename: "vm-100-disk-5", GuestID: 100, Node: "pve", SizeInKibibytes: gibibyte, Storage: "local-lvm"}
+	}
+	tests := []struct {
+		name   string
+		input  func() ConfigContent_Volume
+		output error
+	}{
+		{name: `Valid`,
+			input: valid},
+		{name: `Valid Format`,
+			input: func() ConfigContent_Volume { c := valid(); c.Format = QemuDiskFormat_Raw; return c }},
+		{name: `Invalid Node`,
+			input:  func() ConfigContent_Volume { c := valid(); c.Node = ""; return c },
+			output: errors.New("the value of (Node) may not be empty")},
+		{name: `Invalid Storage`,
+			input:  func() ConfigContent_Volume { c := valid(); c.Storage = ""; return c },
+			output: errors.New("the value of (Storage) may not be empty")},
+		{name: `Invalid GuestID`,
+			input:  func() ConfigContent_Volume { c := valid(); c.GuestID = 0; return c },
+			output: errors.New(ConfigContent_Volume_Error_GuestID)},
+		{name: `Invalid Filename prefix`,
+			input:  func() ConfigContent_Volume { c := valid(); c.Filename = "disk-100"; return c },
+			output: errors.New(ConfigContent_Volume_Error_Filename)},
+		{name: `Invalid Filename guest id`,
+			input:  func() ConfigContent_Volume { c := valid(); c.Filename = "vm-101-disk-0"; return c },
+			output: errors.New(ConfigContent_Volume_Error_Filename)},
+		{name: `Invalid Format`,
+			input:  func() ConfigContent_Volume { c := valid(); c.Format = "iso"; return c },
+			output: QemuDiskFormat("iso").Validate()},
+		{name: `Invalid Size`,
+			input:  func() ConfigContent_Volume { c := valid(); c.SizeInKibibytes = 4096; return c },
+			output: errors.New(QemuDiskSize_Error_Minimum)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input().Validate())
+		})
+	}
+}
+
+func Test_ConfigContent_VolumeCopy(t *testing.T) {
+	content := ConfigContent_VolumeCopy{Node: "pve1", SourceVolumeId: "local-lvm:vm-100-disk-0", TargetFilename: "vm-200-disk-0", TargetStorage: "ceph"}
+	require.NoError(t, content.Validate())
+	require.Equal(t, "ceph:vm-200-disk-0", content.TargetVolumeId())
+	require.Equal(t, map[string]interface{}{"target": "ceph:vm-200-disk-0"}, content.mapToApiValues())
+	content.TargetNode = "pve2"
+	require.Equal(t, map[string]interface{}{"target": "ceph:vm-200-disk-0", "target_node": "pve2"}, content.mapToApiValues())
+	content.TargetStorage = ""
+	require.Equal(t, errors.New("the value of (TargetStorage) may not be empty"), content.Validate())
+}
+
+func Test_Content_VolumeAttributes(t *testing.T) {
+	require.Equal(t, map[string]interface{}{}, Content_VolumeAttributes{}.mapToApiValues())
+	require.Equal(t,
+		map[string]interface{}{"notes": "", "protected": true},
+		Content_VolumeAttributes{Notes: util.Pointer(""), Protected: util.Pointer(true)}.mapToApiValues())
+	require.Equal(t,
+		Content_VolumeAttributes{Notes: util.Pointer("golden image"), Protected: util.Pointer(true)},
+		Content_VolumeAttributes{}.mapToSDK(map[string]interface{}{"notes": "golden image", "protected": float64(1), "size": float64(1024)}))
+	require.Equal(t,
+		Content_VolumeAttributes{Notes: util.Pointer(""), Protected: util.Pointer(false)},
+		Content_VolumeAttributes{}.mapToSDK(map[string]interface{}{}))
+}
+
+func Test_volumeUrl(t *testing.T) {
+	require.Equal(t, "/nodes/pve/storage/local/content/local:100%2Fvm-100-disk-0.qcow2", volumeUrl("pve", "local:100/vm-100-disk-0.qcow2"))
+}
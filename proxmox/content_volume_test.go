@@ -0,0 +1,117 @@
+package proxmox
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Content_Volume_mapToSDK(t *testing.T) {
+	created := time.Unix(1714557600, 0)
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output Content_Volume
+	}{
+		{name: `Linked clone disk`,
+			input: map[string]interface{}{
+				"content": "images",
+				"format":  "raw",
+				"parent":  "base-100-disk-0@__base__",
+				"size":    float64(34359738368),
+				"used":    float64(1073741824),
+				"vmid":    float64(101),
+				"volid":   "local-lvm:vm-101-disk-0"},
+			output: Content_Volume{
+				Content:   ContentType_DiskImage,
+				Format:    "raw",
+				GuestID:   101,
+				Parent:    "base-100-disk-0@__base__",
+				SizeBytes: 34359738368,
+				UsedBytes: 1073741824,
+				VolumeId:  "local-lvm:vm-101-disk-0"}},
+		{name: `Encrypted backup`,
+			input: map[string]interface{}{
+				"content":      "backup",
+				"ctime":        float64(1714557600),
+				"encrypted":    "ab:cd:ef",
+				"format":       "pbs-vm",
+				"notes":        "weekly",
+				"protected":    float64(1),
+				"size":         float64(1024),
+				"verification": map[string]interface{}{"state": "failed", "upid": "UPID:pbs:1"},
+				"vmid":         float64(100),
+				"volid":        "pbs:backup/vm/100/2024-05-01T10:00:00Z"},
+			output: Content_Volume{
+				Content:      ContentType_Backup,
+				CreationTime: &created,
+				Encrypted:    true,
+				Format:       "pbs-vm",
+				GuestID:      100,
+				Notes:        "weekly",
+				Protected:    true,
+				SizeBytes:    1024,
+				Verification: &Content_BackupVerification{State: "failed", UpID: "UPID:pbs:1"},
+				VolumeId:     "pbs:backup/vm/100/2024-05-01T10:00:00Z"}},
+		{name: `Iso`,
+			input: map[string]interface{}{
+				"content": "iso",
+				"format":  "iso",
+				"size":    float64(2048),
+				"volid":   "local:iso/debian.iso"},
+			output: Content_Volume{
+				Content:   ContentType_Iso,
+				Format:    "iso",
+				SizeBytes: 2048,
+				VolumeId:  "local:iso/debian.iso"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, Content_Volume{}.mapToSDK(test.input))
+		})
+	}
+}
+
+func Test_Content_VolumeFilter_mapToApiValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  Content_VolumeFilter
+		output url.Values
+		err    error
+	}{
+		{name: `Empty`,
+			output: url.Values{}},
+		{name: `Full`,
+			input:  Content_VolumeFilter{Content: ContentType_Container, GuestID: 100},
+			output: url.Values{"content": []string{"rootdir"}, "vmid": []string{"100"}}},
+		{name: `Invalid Content`,
+			input: Content_VolumeFilter{Content: "invalid"},
+			err:   errors.New("value should be one of (" + ContentType("").enumList() + ")")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params, err := test.input.mapToApiValues()
+			require.Equal(t, test.err, err)
+			require.Equal(t, test.output, params)
+		})
+	}
+}
+
+func Test_orphanedVolumes(t *testing.T) {
+	volumes := []Content_Volume{
+		{VolumeId: "local:iso/debian.iso"},
+		{VolumeId: "local-lvm:vm-100-disk-0", GuestID: 100},
+		{VolumeId: "local-lvm:vm-101-disk-0", GuestID: 101},
+		{VolumeId: "local-lvm:vm-102-disk-0", GuestID: 102},
+		{VolumeId: "local-lvm:vm-102-disk-1", GuestID: 102},
+	}
+	guests := []GuestResource{{Id: 100}, {Id: 101}}
+	require.Equal(t, []Content_Volume{
+		{VolumeId: "local-lvm:vm-102-disk-0", GuestID: 102},
+		{VolumeId: "local-lvm:vm-102-disk-1", GuestID: 102},
+	}, orphanedVolumes(volumes, guests))
+	require.Equal(t, []Content_Volume{}, orphanedVolumes(volumes[:3], guests))
+}
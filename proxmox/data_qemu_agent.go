@@ -1,23 +1,63 @@
 package proxmox
 
 import (
+	"errors"
 	"net"
 	"strconv"
+	"strings"
 )
 
 func (vmr *VmRef) GetAgentInformation(c *Client, statistics bool) ([]AgentNetworkInterface, error) {
 	if err := c.CheckVmRef(vmr); err != nil {
 		return nil, err
 	}
+	params, err := vmr.agentGet(c, "network-get-interfaces")
+	if err != nil {
+		return nil, err
+	}
+	return AgentNetworkInterface{}.mapToSDK(params, statistics), nil
+}
+
+const AgentCommand_Error_NotSupported string = "guest agent does not support command: "
+
+// Executes a read only guest agent command, does not check the vm reference.
+func (vmr *VmRef) agentGet(c *Client, command string) (map[string]interface{}, error) {
 	vmid := strconv.FormatInt(int64(vmr.vmId), 10)
 	params, err := c.GetItemConfigMapStringInterface(
-		"/nodes/"+vmr.node+"/qemu/"+vmid+"/agent/network-get-interfaces", "guest agent", "data",
+		"/nodes/"+vmr.node+"/qemu/"+vmid+"/agent/"+command, "guest agent", "data",
 		"500 QEMU guest agent is not running",
-		"500 VM "+vmid+" is not running")
+		"500 VM "+vmid+" is not running",
+		"has not been found",
+		"has been disabled")
+	if err != nil {
+		return nil, agentCommandError(command, err)
+	}
+	return params, nil
+}
+
+// Executes a guest agent command that modifies the guest, does not check the vm reference.
+func (vmr *VmRef) agentPost(c *Client, command string, params map[string]interface{}) (map[string]interface{}, error) {
+	reqbody := ParamsToBody(params)
+	resp, err := c.session.Post("/nodes/"+vmr.node+"/qemu/"+strconv.FormatInt(int64(vmr.vmId), 10)+"/agent/"+command, nil, nil, &reqbody)
+	if err != nil {
+		return nil, agentCommandError(command, err)
+	}
+	taskResponse, err := ResponseJSON(resp)
 	if err != nil {
 		return nil, err
 	}
-	return AgentNetworkInterface{}.mapToSDK(params, statistics), nil
+	// some commands like file-write don't return any data
+	data, _ := taskResponse["data"].(map[string]interface{})
+	return data, nil
+}
+
+// The guest agent reports commands it doesn't know or that are blocked in its configuration.
+// These are replaced by a clear error, any other error is returned as is.
+func agentCommandError(command string, err error) error {
+	if strings.Contains(err.Error(), "has not been found") || strings.Contains(err.Error(), "has been disabled") {
+		return errors.New(AgentCommand_Error_NotSupported + strings.SplitN(command, "?", 2)[0])
+	}
+	return err
 }
 
 type AgentNetworkInterface struct {
@@ -0,0 +1,122 @@
+package proxmox
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+)
+
+// time between two consecutive exec-status requests.
+var agentExecStatusCheckInterval = 1 * time.Second
+
+const (
+	AgentExec_Error_CommandEmpty string = "guest agent exec command may not be empty"
+	AgentExec_Error_NoPid        string = "guest agent exec did not return a pid"
+	AgentExec_Error_Timeout      string = "timeout while waiting for guest agent exec to finish"
+)
+
+// Executes the command in the guest through the qemu guest agent and blocks until it has exited.
+// stdin is passed to the command when not empty.
+// When timeout is 0 the TaskTimeout of the client is used.
+func (vmr *VmRef) AgentExec(c *Client, command []string, stdin string, timeout time.Duration) (*AgentExecStatus, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if len(command) == 0 || command[0] == "" {
+		return nil, errors.New(AgentExec_Error_CommandEmpty)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return nil, err
+	}
+	if timeout == 0 {
+		timeout = time.Duration(c.TaskTimeout) * time.Second
+	}
+	pid, err := vmr.agentExecStart(c, command, stdin)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		status, err := vmr.AgentExecStatus(c, pid)
+		if err != nil {
+			return nil, err
+		}
+		if status.Exited {
+			return status, nil
+		}
+		if time.Now().Add(agentExecStatusCheckInterval).After(deadline) {
+			return status, errors.New(AgentExec_Error_Timeout)
+		}
+		time.Sleep(agentExecStatusCheckInterval)
+	}
+}
+
+// Returns the status of the command with the given pid, previously started through the qemu guest agent.
+func (vmr *VmRef) AgentExecStatus(c *Client, pid uint) (*AgentExecStatus, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return nil, err
+	}
+	params, err := vmr.agentGet(c, "exec-status?pid="+strconv.FormatUint(uint64(pid), 10))
+	if err != nil {
+		return nil, err
+	}
+	return AgentExecStatus{}.mapToSDK(params, pid), nil
+}
+
+func (vmr *VmRef) agentExecStart(c *Client, command []string, stdin string) (uint, error) {
+	params := map[string]interface{}{"command": command}
+	if stdin != "" {
+		params["input-data"] = stdin
+	}
+	data, err := vmr.agentPost(c, "exec", params)
+	if err != nil {
+		return 0, err
+	}
+	pid, ok := data["pid"].(float64)
+	if !ok {
+		return 0, errors.New(AgentExec_Error_NoPid)
+	}
+	return uint(pid), nil
+}
+
+type AgentExecStatus struct {
+	Pid             uint   `json:"pid"`
+	Exited          bool   `json:"exited"`
+	ExitCode        *int   `json:"exitcode,omitempty"` // nil when the command has not exited or was terminated by a signal
+	Signal          *int   `json:"signal,omitempty"`   // nil when the command was not terminated by a signal
+	Stdout          string `json:"stdout,omitempty"`
+	StdoutTruncated bool   `json:"stdout_truncated"`
+	Stderr          string `json:"stderr,omitempty"`
+	StderrTruncated bool   `json:"stderr_truncated"`
+}
+
+func (AgentExecStatus) mapToSDK(params map[string]interface{}, pid uint) *AgentExecStatus {
+	status := AgentExecStatus{Pid: pid}
+	if v, isSet := params["exited"]; isSet {
+		status.Exited = apiBool(v)
+	}
+	if v, isSet := params["exitcode"]; isSet {
+		status.ExitCode = util.Pointer(int(v.(float64)))
+	}
+	if v, isSet := params["signal"]; isSet {
+		status.Signal = util.Pointer(int(v.(float64)))
+	}
+	if v, isSet := params["out-data"]; isSet {
+		status.Stdout = v.(string)
+	}
+	if v, isSet := params["out-truncated"]; isSet {
+		status.StdoutTruncated = apiBool(v)
+	}
+	if v, isSet := params["err-data"]; isSet {
+		status.Stderr = v.(string)
+	}
+	if v, isSet := params["err-truncated"]; isSet {
+		status.StderrTruncated = apiBool(v)
+	}
+	return &status
+}
@@ -0,0 +1,87 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AgentExecStatus_mapToSDK(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output *AgentExecStatus
+	}{
+		{name: `Running`,
+			input:  map[string]interface{}{"exited": float64(0)},
+			output: &AgentExecStatus{Pid: 10}},
+		{name: `Exited bool`,
+			input:  map[string]interface{}{"exited": true, "exitcode": float64(0)},
+			output: &AgentExecStatus{Pid: 10, Exited: true, ExitCode: util.Pointer(0)}},
+		{name: `Exited int`,
+			input:  map[string]interface{}{"exited": float64(1), "exitcode": float64(3)},
+			output: &AgentExecStatus{Pid: 10, Exited: true, ExitCode: util.Pointer(3)}},
+		{name: `Signal`,
+			input:  map[string]interface{}{"exited": float64(1), "signal": float64(9)},
+			output: &AgentExecStatus{Pid: 10, Exited: true, Signal: util.Pointer(9)}},
+		{name: `Output without newline`,
+			input: map[string]interface{}{
+				"exited":   true,
+				"exitcode": float64(1),
+				"out-data": "abcd",
+				"err-data": "ZXJyb3I="},
+			output: &AgentExecStatus{Pid: 10, Exited: true, ExitCode: util.Pointer(1),
+				Stdout: "abcd",
+				Stderr: "ZXJyb3I="}},
+		{name: `Output plain`,
+			input: map[string]interface{}{
+				"exited":   true,
+				"exitcode": float64(0),
+				"out-data": "root\n"},
+			output: &AgentExecStatus{Pid: 10, Exited: true, ExitCode: util.Pointer(0),
+				Stdout: "root\n"}},
+		{name: `Truncated`,
+			input: map[string]interface{}{
+				"exited":        true,
+				"exitcode":      float64(0),
+				"out-truncated": float64(1),
+				"err-truncated": true},
+			output: &AgentExecStatus{Pid: 10, Exited: true, ExitCode: util.Pointer(0),
+				StdoutTruncated: true,
+				StderrTruncated: true}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, AgentExecStatus{}.mapToSDK(test.input, 10))
+		})
+	}
+}
+
+func Test_VmRef_AgentExec(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  *Client
+		command []string
+		err     error
+	}{
+		{name: `nil Client`,
+			command: []string{"ls"},
+			err:     errors.New(Client_Error_Nil)},
+		{name: `Command nil`,
+			client: &Client{},
+			err:    errors.New(AgentExec_Error_CommandEmpty)},
+		{name: `Command empty`,
+			client:  &Client{},
+			command: []string{""},
+			err:     errors.New(AgentExec_Error_CommandEmpty)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewVmRef(100).AgentExec(test.client, test.command, "", time.Second)
+			require.Equal(t, test.err, err)
+		})
+	}
+}
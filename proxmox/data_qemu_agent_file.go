@@ -0,0 +1,389 @@
+package proxmox
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+)
+
+const (
+	// Proxmox limits the base64 encoded content of file-write to 61440 characters.
+	AgentFileWriteMaxBytes uint = 46080
+	// Default amount of bytes read per chunk by AgentFileReadChunked().
+	AgentFileReadDefaultChunkBytes uint = 4 * 1048576
+	// The guest agent truncates exec output at 16 MiB, which has to fit the base64 encoded chunk.
+	AgentFileReadMaxChunkBytes uint = 12 * 1048576
+)
+
+const (
+	AgentFile_Error_ChunkTooLarge string = "chunk size may not be larger than 12 MiB"
+	AgentFile_Error_PathEmpty     string = "file path may not be empty"
+	AgentFile_Error_TooLarge      string = "content may not be larger than 46080 bytes"
+	AgentFile_Error_WriterNil     string = "writer may not be nil"
+)
+
+// Content of a file read through the qemu guest agent.
+type AgentFileContent struct {
+	Content   []byte `json:"content"`
+	Truncated bool   `json:"truncated"` // true when the file was larger than the maximum Proxmox returns
+}
+
+func (AgentFileContent) mapToSDK(params map[string]interface{}) *AgentFileContent {
+	file := AgentFileContent{}
+	if v, isSet := params["content"]; isSet {
+		file.Content = []byte(v.(string))
+	}
+	if v, isSet := params["truncated"]; isSet {
+		file.Truncated = apiBool(v)
+	}
+	return &file
+}
+
+// Reads the file from the guest through the qemu guest agent.
+// Proxmox returns at most 16 MiB, larger files are marked as truncated. Use AgentFileReadChunked() for those.
+func (vmr *VmRef) AgentFileRead(c *Client, file string) (*AgentFileContent, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if file == "" {
+		return nil, errors.New(AgentFile_Error_PathEmpty)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return nil, err
+	}
+	params, err := vmr.agentGet(c, "file-read?file="+url.QueryEscape(file))
+	if err != nil {
+		return nil, err
+	}
+	return AgentFileContent{}.mapToSDK(params), nil
+}
+
+// Reads the file from the guest in chunks of chunkSize bytes and writes them to w.
+// The file-read api has no offset, so each chunk is read with dd and base64 inside the guest, which requires a POSIX guest.
+// When chunkSize is 0 AgentFileReadDefaultChunkBytes is used.
+// Returns the number of bytes written to w.
+func (vmr *VmRef) AgentFileReadChunked(c *Client, file string, chunkSize uint, w io.Writer) (uint64, error) {
+	if c == nil {
+		return 0, errors.New(Client_Error_Nil)
+	}
+	if file == "" {
+		return 0, errors.New(AgentFile_Error_PathEmpty)
+	}
+	if w == nil {
+		return 0, errors.New(AgentFile_Error_WriterNil)
+	}
+	if chunkSize == 0 {
+		chunkSize = AgentFileReadDefaultChunkBytes
+	}
+	if chunkSize > AgentFileReadMaxChunkBytes {
+		return 0, errors.New(AgentFile_Error_ChunkTooLarge)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return 0, err
+	}
+	var written uint64
+	for chunk := uint(0); ; chunk++ {
+		status, err := vmr.AgentExec(c, AgentFileContent{}.chunkCommand(file, chunkSize, chunk), "", 0)
+		if err != nil {
+			return written, err
+		}
+		if status.ExitCode == nil || *status.ExitCode != 0 {
+			return written, errors.New("reading chunk " + strconv.FormatUint(uint64(chunk), 10) + " of " + file + " failed: " + status.Stderr)
+		}
+		if status.StdoutTruncated {
+			return written, errors.New("chunk " + strconv.FormatUint(uint64(chunk), 10) + " of " + file + " was truncated by the guest agent")
+		}
+		// base64 wraps its output in lines, the newlines have to be removed before decoding.
+		data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(status.Stdout, "\n", ""))
+		if err != nil {
+			return written, err
+		}
+		n, err := w.Write(data)
+		written += uint64(n)
+		if err != nil {
+			return written, err
+		}
+		if uint(len(data)) < chunkSize {
+			return written, nil
+		}
+	}
+}
+
+func (AgentFileContent) chunkCommand(file string, chunkSize, chunk uint) []string {
+	return []string{"sh", "-c",
+		`dd if="$1" bs=` + strconv.FormatUint(uint64(chunkSize), 10) + ` skip=` + strconv.FormatUint(uint64(chunk), 10) + ` count=1 2>/dev/null | base64`,
+		"sh", file}
+}
+
+// Writes everything from content to the file in the guest through the qemu guest agent.
+// An existing file is overwritten. Proxmox limits the content to AgentFileWriteMaxBytes.
+func (vmr *VmRef) AgentFileWrite(c *Client, file string, content io.Reader) error {
+	if c == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	if file == "" {
+		return errors.New(AgentFile_Error_PathEmpty)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return err
+	}
+	var data []byte
+	if content != nil {
+		var err error
+		// read one byte more than allowed to detect content that is too large
+		if data, err = io.ReadAll(io.LimitReader(content, int64(AgentFileWriteMaxBytes)+1)); err != nil {
+			return err
+		}
+		if uint(len(data)) > AgentFileWriteMaxBytes {
+			return errors.New(AgentFile_Error_TooLarge)
+		}
+	}
+	_, err := vmr.agentPost(c, "file-write", map[string]interface{}{
+		"file":    file,
+		"content": base64.StdEncoding.EncodeToString(data),
+		"encode":  false})
+	return err
+}
+
+// Freezes all freezable filesystems in the guest, returns the number of frozen filesystems.
+func (vmr *VmRef) AgentFsFreeze(c *Client) (uint, error) {
+	return vmr.agentFsFreezeCommand(c, "fsfreeze-freeze")
+}
+
+// Thaws all frozen filesystems in the guest, returns the number of thawed filesystems.
+func (vmr *VmRef) AgentFsThaw(c *Client) (uint, error) {
+	return vmr.agentFsFreezeCommand(c, "fsfreeze-thaw")
+}
+
+func (vmr *VmRef) agentFsFreezeCommand(c *Client, command string) (uint, error) {
+	if c == nil {
+		return 0, errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return 0, err
+	}
+	params, err := vmr.agentPost(c, command, nil)
+	if err != nil {
+		return 0, err
+	}
+	if v, isSet := params["result"]; isSet {
+		return uint(v.(float64)), nil
+	}
+	return 0, nil
+}
+
+// Freezes the filesystems of the guest, runs fn and thaws the filesystems again, even when fn fails.
+// Useful for taking consistent storage snapshots.
+func (vmr *VmRef) AgentFsFrozen(c *Client, fn func() error) error {
+	if _, err := vmr.AgentFsFreeze(c); err != nil {
+		return err
+	}
+	fnErr := fn()
+	if _, err := vmr.AgentFsThaw(c); err != nil {
+		if fnErr != nil {
+			return errors.New(fnErr.Error() + ", " + err.Error())
+		}
+		return err
+	}
+	return fnErr
+}
+
+// Returns if the filesystems of the guest are frozen or thawed.
+func (vmr *VmRef) AgentFsFreezeStatus(c *Client) (AgentFsFreezeStatus, error) {
+	if c == nil {
+		return "", errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return "", err
+	}
+	params, err := vmr.agentPost(c, "fsfreeze-status", nil)
+	if err != nil {
+		return "", err
+	}
+	if v, isSet := params["result"]; isSet {
+		return AgentFsFreezeStatus(v.(string)), nil
+	}
+	return "", nil
+}
+
+// Discards unused blocks of all mounted filesystems in the guest.
+func (vmr *VmRef) AgentFsTrim(c *Client) ([]AgentFsTrimResult, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return nil, err
+	}
+	params, err := vmr.agentPost(c, "fstrim", nil)
+	if err != nil {
+		return nil, err
+	}
+	return AgentFsTrimResult{}.mapToSDK(params), nil
+}
+
+// Returns the mounted filesystems of the guest.
+func (vmr *VmRef) AgentFsInfo(c *Client) ([]AgentFileSystem, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return nil, err
+	}
+	params, err := vmr.agentGet(c, "get-fsinfo")
+	if err != nil {
+		return nil, err
+	}
+	return AgentFileSystem{}.mapToSDK(params), nil
+}
+
+type AgentFsFreezeStatus string // enum
+
+const (
+	AgentFsFreezeStatus_Frozen AgentFsFreezeStatus = "frozen"
+	AgentFsFreezeStatus_Thawed AgentFsFreezeStatus = "thawed"
+)
+
+type AgentFsTrimResult struct {
+	Path         string `json:"path"`
+	TrimmedBytes *uint  `json:"trimmed,omitempty"`
+	MinimumBytes *uint  `json:"minimum,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+func (AgentFsTrimResult) mapToSDK(params map[string]interface{}) []AgentFsTrimResult {
+	result, isSet := params["result"].(map[string]interface{})
+	if !isSet {
+		return nil
+	}
+	paths, _ := result["paths"].([]interface{})
+	if len(paths) == 0 {
+		return nil
+	}
+	trims := make([]AgentFsTrimResult, len(paths))
+	for i, e := range paths {
+		path := e.(map[string]interface{})
+		if v, isSet := path["path"]; isSet {
+			trims[i].Path = v.(string)
+		}
+		if v, isSet := path["trimmed"]; isSet {
+			trims[i].TrimmedBytes = util.Pointer(uint(v.(float64)))
+		}
+		if v, isSet := path["minimum"]; isSet {
+			trims[i].MinimumBytes = util.Pointer(uint(v.(float64)))
+		}
+		if v, isSet := path["error"]; isSet {
+			trims[i].Error = v.(string)
+		}
+	}
+	return trims
+}
+
+type AgentFileSystem struct {
+	Name       string                `json:"name"`
+	MountPoint string                `json:"mountpoint"`
+	Type       string                `json:"type"`
+	UsedBytes  *uint                 `json:"used_bytes,omitempty"`  // nil when the guest agent does not report usage
+	TotalBytes *uint                 `json:"total_bytes,omitempty"` // nil when the guest agent does not report usage
+	Disks      []AgentFileSystemDisk `json:"disks,omitempty"`
+}
+
+func (AgentFileSystem) mapToSDK(params map[string]interface{}) []AgentFileSystem {
+	raw, _ := params["result"].([]interface{})
+	if len(raw) == 0 {
+		return nil
+	}
+	fileSystems := make([]AgentFileSystem, len(raw))
+	for i, e := range raw {
+		fs := e.(map[string]interface{})
+		if v, isSet := fs["name"]; isSet {
+			fileSystems[i].Name = v.(string)
+		}
+		if v, isSet := fs["mountpoint"]; isSet {
+			fileSystems[i].MountPoint = v.(string)
+		}
+		if v, isSet := fs["type"]; isSet {
+			fileSystems[i].Type = v.(string)
+		}
+		if v, isSet := fs["used-bytes"]; isSet {
+			fileSystems[i].UsedBytes = util.Pointer(uint(v.(float64)))
+		}
+		if v, isSet := fs["total-bytes"]; isSet {
+			fileSystems[i].TotalBytes = util.Pointer(uint(v.(float64)))
+		}
+		if v, isSet := fs["disk"]; isSet {
+			fileSystems[i].Disks = AgentFileSystemDisk{}.mapToSDK(v.([]interface{}))
+		}
+	}
+	return fileSystems
+}
+
+type AgentFileSystemDisk struct {
+	BusType       string           `json:"bus_type"`
+	Bus           uint             `json:"bus"`
+	Target        uint             `json:"target"`
+	Unit          uint             `json:"unit"`
+	Serial        string           `json:"serial,omitempty"`
+	Device        string           `json:"device,omitempty"`
+	PciController *AgentPciAddress `json:"pci_controller,omitempty"`
+}
+
+func (AgentFileSystemDisk) mapToSDK(params []interface{}) []AgentFileSystemDisk {
+	if len(params) == 0 {
+		return nil
+	}
+	disks := make([]AgentFileSystemDisk, len(params))
+	for i, e := range params {
+		disk := e.(map[string]interface{})
+		if v, isSet := disk["bus-type"]; isSet {
+			disks[i].BusType = v.(string)
+		}
+		if v, isSet := disk["bus"]; isSet {
+			disks[i].Bus = uint(v.(float64))
+		}
+		if v, isSet := disk["target"]; isSet {
+			disks[i].Target = uint(v.(float64))
+		}
+		if v, isSet := disk["unit"]; isSet {
+			disks[i].Unit = uint(v.(float64))
+		}
+		if v, isSet := disk["serial"]; isSet {
+			disks[i].Serial = v.(string)
+		}
+		if v, isSet := disk["dev"]; isSet {
+			disks[i].Device = v.(string)
+		}
+		if v, isSet := disk["pci-controller"]; isSet {
+			disks[i].PciController = AgentPciAddress{}.mapToSDK(v.(map[string]interface{}))
+		}
+	}
+	return disks
+}
+
+type AgentPciAddress struct {
+	Domain   uint `json:"domain"`
+	Bus      uint `json:"bus"`
+	Slot     uint `json:"slot"`
+	Function uint `json:"function"`
+}
+
+func (AgentPciAddress) mapToSDK(params map[string]interface{}) *AgentPciAddress {
+	address := AgentPciAddress{}
+	if v, isSet := params["domain"]; isSet {
+		address.Domain = uint(v.(float64))
+	}
+	if v, isSet := params["bus"]; isSet {
+		address.Bus = uint(v.(float64))
+	}
+	if v, isSet := params["slot"]; isSet {
+		address.Slot = uint(v.(float64))
+	}
+	if v, isSet := params["function"]; isSet {
+		address.Function = uint(v.(float64))
+	}
+	return &address
+}
@@ -0,0 +1,195 @@
+package proxmox
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AgentFileContent_mapToSDK(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output *AgentFileContent
+	}{
+		{name: `Empty`,
+			input:  map[string]interface{}{},
+			output: &AgentFileContent{}},
+		{name: `Base64 like`,
+			input:  map[string]interface{}{"content": "aGVsbG8="},
+			output: &AgentFileContent{Content: []byte("aGVsbG8=")}},
+		{name: `Plain`,
+			input:  map[string]interface{}{"content": "127.0.0.1 localhost\n"},
+			output: &AgentFileContent{Content: []byte("127.0.0.1 localhost\n")}},
+		{name: `Truncated`,
+			input:  map[string]interface{}{"content": "test", "truncated": float64(1)},
+			output: &AgentFileContent{Content: []byte("test"), Truncated: true}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, AgentFileContent{}.mapToSDK(test.input))
+		})
+	}
+}
+
+func Test_AgentFileContent_chunkCommand(t *testing.T) {
+	require.Equal(t,
+		[]string{"sh", "-c", `dd if="$1" bs=1024 skip=3 count=1 2>/dev/null | base64`, "sh", "/var/log/my file.log"},
+		AgentFileContent{}.chunkCommand("/var/log/my file.log", 1024, 3))
+}
+
+func Test_VmRef_AgentFileReadChunked(t *testing.T) {
+	tests := []struct {
+		name      string
+		client    *Client
+		file      string
+		chunkSize uint
+		writer    *bytes.Buffer
+		err       error
+	}{
+		{name: `nil Client`,
+			file:   "/etc/hosts",
+			writer: &bytes.Buffer{},
+			err:    errors.New(Client_Error_Nil)},
+		{name: `File empty`,
+			client: &Client{},
+			writer: &bytes.Buffer{},
+			err:    errors.New(AgentFile_Error_PathEmpty)},
+		{name: `Writer nil`,
+			client: &Client{},
+			file:   "/etc/hosts",
+			err:    errors.New(AgentFile_Error_WriterNil)},
+		{name: `Chunk too large`,
+			client:    &Client{},
+			file:      "/etc/hosts",
+			chunkSize: AgentFileReadMaxChunkBytes + 1,
+			writer:    &bytes.Buffer{},
+			err:       errors.New(AgentFile_Error_ChunkTooLarge)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var written uint64
+			var err error
+			if test.writer == nil {
+				written, err = NewVmRef(100).AgentFileReadChunked(test.client, test.file, test.chunkSize, nil)
+			} else {
+				written, err = NewVmRef(100).AgentFileReadChunked(test.client, test.file, test.chunkSize, test.writer)
+			}
+			require.Equal(t, test.err, err)
+			require.Equal(t, uint64(0), written)
+		})
+	}
+}
+
+func Test_VmRef_AgentFileWrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  *Client
+		file    string
+		content string
+		err     error
+	}{
+		{name: `nil Client`,
+			file: "/etc/hosts",
+			err:  errors.New(Client_Error_Nil)},
+		{name: `File empty`,
+			client: &Client{},
+			err:    errors.New(AgentFile_Error_PathEmpty)},
+		{name: `Too large`,
+			client:  &Client{},
+			file:    "/etc/hosts",
+			content: strings.Repeat("a", int(AgentFileWriteMaxBytes)+1),
+			err:     errors.New(AgentFile_Error_TooLarge)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vmr := &VmRef{vmId: 100, node: "pve", vmType: "qemu"}
+			require.Equal(t, test.err, vmr.AgentFileWrite(test.client, test.file, strings.NewReader(test.content)))
+		})
+	}
+}
+
+func Test_AgentFsTrimResult_mapToSDK(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output []AgentFsTrimResult
+	}{
+		{name: `Empty`,
+			input: map[string]interface{}{}},
+		{name: `No paths`,
+			input: map[string]interface{}{"result": map[string]interface{}{"paths": []interface{}{}}}},
+		{name: `Full`,
+			input: map[string]interface{}{"result": map[string]interface{}{"paths": []interface{}{
+				map[string]interface{}{"path": "/", "trimmed": float64(1048576), "minimum": float64(0)},
+				map[string]interface{}{"path": "/boot", "error": "trim not supported"}}}},
+			output: []AgentFsTrimResult{
+				{Path: "/", TrimmedBytes: util.Pointer(uint(1048576)), MinimumBytes: util.Pointer(uint(0))},
+				{Path: "/boot", Error: "trim not supported"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, AgentFsTrimResult{}.mapToSDK(test.input))
+		})
+	}
+}
+
+func Test_AgentFileSystem_mapToSDK(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output []AgentFileSystem
+	}{
+		{name: `Empty`,
+			input: map[string]interface{}{"result": []interface{}{}}},
+		{name: `Full`,
+			input: map[string]interface{}{"result": []interface{}{
+				map[string]interface{}{
+					"name":        "sda1",
+					"mountpoint":  "/",
+					"type":        "ext4",
+					"used-bytes":  float64(2147483648),
+					"total-bytes": float64(10737418240),
+					"disk": []interface{}{map[string]interface{}{
+						"bus-type": "scsi",
+						"bus":      float64(0),
+						"target":   float64(0),
+						"unit":     float64(1),
+						"serial":   "drive-scsi1",
+						"dev":      "/dev/sda1",
+						"pci-controller": map[string]interface{}{
+							"domain":   float64(0),
+							"bus":      float64(1),
+							"slot":     float64(2),
+							"function": float64(0)}}}},
+				map[string]interface{}{
+					"name":       "tmpfs",
+					"mountpoint": "/run",
+					"type":       "tmpfs",
+					"disk":       []interface{}{}}}},
+			output: []AgentFileSystem{
+				{Name: "sda1",
+					MountPoint: "/",
+					Type:       "ext4",
+					UsedBytes:  util.Pointer(uint(2147483648)),
+					TotalBytes: util.Pointer(uint(10737418240)),
+					Disks: []AgentFileSystemDisk{{
+						BusType:       "scsi",
+						Unit:          1,
+						Serial:        "drive-scsi1",
+						Device:        "/dev/sda1",
+						PciController: &AgentPciAddress{Bus: 1, Slot: 2}}}},
+				{Name: "tmpfs",
+					MountPoint: "/run",
+					Type:       "tmpfs"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, AgentFileSystem{}.mapToSDK(test.input))
+		})
+	}
+}
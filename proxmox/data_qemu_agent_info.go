@@ -0,0 +1,268 @@
+package proxmox
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Returns the operating system information reported by the guest agent.
+func (vmr *VmRef) AgentOsInfo(c *Client) (*AgentOsInfo, error) {
+	params, err := vmr.agentInfo(c, "get-osinfo")
+	if err != nil {
+		return nil, err
+	}
+	return AgentOsInfo{}.mapToSDK(params), nil
+}
+
+// Returns the host name of the guest.
+func (vmr *VmRef) AgentHostName(c *Client) (string, error) {
+	params, err := vmr.agentInfo(c, "get-host-name")
+	if err != nil {
+		return "", err
+	}
+	if result, isSet := params["result"].(map[string]interface{}); isSet {
+		if v, isSet := result["host-name"]; isSet {
+			return v.(string), nil
+		}
+	}
+	return "", nil
+}
+
+// Returns the users currently logged in to the guest.
+func (vmr *VmRef) AgentUsers(c *Client) ([]AgentUser, error) {
+	params, err := vmr.agentInfo(c, "get-users")
+	if err != nil {
+		return nil, err
+	}
+	return AgentUser{}.mapToSDK(params), nil
+}
+
+// Returns the timezone of the guest.
+func (vmr *VmRef) AgentTimezone(c *Client) (*AgentTimezone, error) {
+	params, err := vmr.agentInfo(c, "get-timezone")
+	if err != nil {
+		return nil, err
+	}
+	return AgentTimezone{}.mapToSDK(params), nil
+}
+
+// Returns the current time of the guest.
+func (vmr *VmRef) AgentTime(c *Client) (time.Time, error) {
+	params, err := vmr.agentInfo(c, "get-time")
+	if err != nil {
+		return time.Time{}, err
+	}
+	if v, isSet := params["result"]; isSet {
+		return time.Unix(0, int64(v.(float64))), nil
+	}
+	return time.Time{}, nil
+}
+
+// Returns the virtual cpus of the guest.
+func (vmr *VmRef) AgentVCpus(c *Client) ([]AgentVCpu, error) {
+	params, err := vmr.agentInfo(c, "get-vcpus")
+	if err != nil {
+		return nil, err
+	}
+	return AgentVCpu{}.mapToSDK(params), nil
+}
+
+// Returns the size of the memory blocks of the guest in bytes.
+func (vmr *VmRef) AgentMemoryBlockSize(c *Client) (uint, error) {
+	params, err := vmr.agentInfo(c, "get-memory-block-info")
+	if err != nil {
+		return 0, err
+	}
+	if result, isSet := params["result"].(map[string]interface{}); isSet {
+		if v, isSet := result["size"]; isSet {
+			return uint(v.(float64)), nil
+		}
+	}
+	return 0, nil
+}
+
+// Collects all information the guest agent offers about the guest.
+// Information the guest agent does not support is left empty and the command is added to Unsupported,
+// any other error aborts the collection.
+func (vmr *VmRef) AgentInventory(c *Client) (*AgentInventory, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return nil, err
+	}
+	inventory := AgentInventory{}
+	var err error
+	collectors := []struct {
+		command string
+		collect func() error
+	}{
+		{"get-osinfo", func() (err error) { inventory.OsInfo, err = vmr.AgentOsInfo(c); return }},
+		{"get-host-name", func() (err error) { inventory.HostName, err = vmr.AgentHostName(c); return }},
+		{"get-users", func() (err error) { inventory.Users, err = vmr.AgentUsers(c); return }},
+		{"get-timezone", func() (err error) { inventory.Timezone, err = vmr.AgentTimezone(c); return }},
+		{"get-time", func() (err error) { inventory.Time, err = vmr.AgentTime(c); return }},
+		{"get-vcpus", func() (err error) { inventory.VCpus, err = vmr.AgentVCpus(c); return }},
+		{"get-memory-block-info", func() (err error) { inventory.MemoryBlockSize, err = vmr.AgentMemoryBlockSize(c); return }},
+		{"network-get-interfaces", func() (err error) { inventory.NetworkInterfaces, err = vmr.GetAgentInformation(c, false); return }},
+	}
+	for _, e := range collectors {
+		if err = e.collect(); err != nil {
+			if !agentCommandNotSupported(err) {
+				return nil, err
+			}
+			inventory.Unsupported = append(inventory.Unsupported, e.command)
+		}
+	}
+	return &inventory, nil
+}
+
+func (vmr *VmRef) agentInfo(c *Client, command string) (map[string]interface{}, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return nil, err
+	}
+	return vmr.agentGet(c, command)
+}
+
+// Returns true when the error was caused by the guest agent not supporting the command.
+func agentCommandNotSupported(err error) bool {
+	return strings.HasPrefix(err.Error(), AgentCommand_Error_NotSupported)
+}
+
+// Snapshot of everything the guest agent reports about the guest.
+type AgentInventory struct {
+	OsInfo            *AgentOsInfo            `json:"os_info,omitempty"`
+	HostName          string                  `json:"host_name,omitempty"`
+	Users             []AgentUser             `json:"users,omitempty"`
+	Timezone          *AgentTimezone          `json:"timezone,omitempty"`
+	Time              time.Time               `json:"time"`
+	VCpus             []AgentVCpu             `json:"vcpus,omitempty"`
+	MemoryBlockSize   uint                    `json:"memory_block_size,omitempty"`
+	NetworkInterfaces []AgentNetworkInterface `json:"network_interfaces,omitempty"`
+	Unsupported       []string                `json:"unsupported,omitempty"` // guest agent commands the guest does not support
+}
+
+type AgentOsInfo struct {
+	ID            string `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	PrettyName    string `json:"pretty_name,omitempty"`
+	Version       string `json:"version,omitempty"`
+	VersionID     string `json:"version_id,omitempty"`
+	Variant       string `json:"variant,omitempty"`
+	VariantID     string `json:"variant_id,omitempty"`
+	KernelRelease string `json:"kernel_release,omitempty"`
+	KernelVersion string `json:"kernel_version,omitempty"`
+	Machine       string `json:"machine,omitempty"`
+}
+
+func (AgentOsInfo) mapToSDK(params map[string]interface{}) *AgentOsInfo {
+	info := AgentOsInfo{}
+	result, _ := params["result"].(map[string]interface{})
+	if v, isSet := result["id"]; isSet {
+		info.ID = v.(string)
+	}
+	if v, isSet := result["name"]; isSet {
+		info.Name = v.(string)
+	}
+	if v, isSet := result["pretty-name"]; isSet {
+		info.PrettyName = v.(string)
+	}
+	if v, isSet := result["version"]; isSet {
+		info.Version = v.(string)
+	}
+	if v, isSet := result["version-id"]; isSet {
+		info.VersionID = v.(string)
+	}
+	if v, isSet := result["variant"]; isSet {
+		info.Variant = v.(string)
+	}
+	if v, isSet := result["variant-id"]; isSet {
+		info.VariantID = v.(string)
+	}
+	if v, isSet := result["kernel-release"]; isSet {
+		info.KernelRelease = v.(string)
+	}
+	if v, isSet := result["kernel-version"]; isSet {
+		info.KernelVersion = v.(string)
+	}
+	if v, isSet := result["machine"]; isSet {
+		info.Machine = v.(string)
+	}
+	return &info
+}
+
+type AgentUser struct {
+	Name      string    `json:"name"`
+	Domain    string    `json:"domain,omitempty"` // only reported by windows guests
+	LoginTime time.Time `json:"login_time"`
+}
+
+func (AgentUser) mapToSDK(params map[string]interface{}) []AgentUser {
+	raw, _ := params["result"].([]interface{})
+	if len(raw) == 0 {
+		return nil
+	}
+	users := make([]AgentUser, len(raw))
+	for i, e := range raw {
+		user := e.(map[string]interface{})
+		if v, isSet := user["user"]; isSet {
+			users[i].Name = v.(string)
+		}
+		if v, isSet := user["domain"]; isSet {
+			users[i].Domain = v.(string)
+		}
+		if v, isSet := user["login-time"]; isSet {
+			seconds := v.(float64)
+			users[i].LoginTime = time.Unix(int64(seconds), int64((seconds-float64(int64(seconds)))*1e9))
+		}
+	}
+	return users
+}
+
+type AgentTimezone struct {
+	Zone   string `json:"zone,omitempty"` // not all guests report the name of the zone
+	Offset int    `json:"offset"`         // offset to UTC in seconds
+}
+
+func (AgentTimezone) mapToSDK(params map[string]interface{}) *AgentTimezone {
+	timezone := AgentTimezone{}
+	result, _ := params["result"].(map[string]interface{})
+	if v, isSet := result["zone"]; isSet {
+		timezone.Zone = v.(string)
+	}
+	if v, isSet := result["offset"]; isSet {
+		timezone.Offset = int(v.(float64))
+	}
+	return &timezone
+}
+
+type AgentVCpu struct {
+	LogicalID  uint `json:"logical_id"`
+	Online     bool `json:"online"`
+	CanOffline bool `json:"can_offline"`
+}
+
+func (AgentVCpu) mapToSDK(params map[string]interface{}) []AgentVCpu {
+	raw, _ := params["result"].([]interface{})
+	if len(raw) == 0 {
+		return nil
+	}
+	cpus := make([]AgentVCpu, len(raw))
+	for i, e := range raw {
+		cpu := e.(map[string]interface{})
+		if v, isSet := cpu["logical-id"]; isSet {
+			cpus[i].LogicalID = uint(v.(float64))
+		}
+		if v, isSet := cpu["online"]; isSet {
+			cpus[i].Online = apiBool(v)
+		}
+		if v, isSet := cpu["can-offline"]; isSet {
+			cpus[i].CanOffline = apiBool(v)
+		}
+	}
+	return cpus
+}
@@ -0,0 +1,147 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_agentCommandError(t *testing.T) {
+	tests := []struct {
+		name         string
+		command      string
+		input        error
+		output       error
+		notSupported bool
+	}{
+		{name: `Not found`,
+			command:      "get-users",
+			input:        errors.New("500 Agent error: The command guest-get-users has not been found"),
+			output:       errors.New(AgentCommand_Error_NotSupported + "get-users"),
+			notSupported: true},
+		{name: `Disabled`,
+			command:      "file-read?file=%2Fetc%2Fhosts",
+			input:        errors.New("500 Agent error: Command guest-file-open has been disabled"),
+			output:       errors.New(AgentCommand_Error_NotSupported + "file-read"),
+			notSupported: true},
+		{name: `Other`,
+			command: "get-users",
+			input:   errors.New("500 QEMU guest agent is not running"),
+			output:  errors.New("500 QEMU guest agent is not running")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := agentCommandError(test.command, test.input)
+			require.Equal(t, test.output, err)
+			require.Equal(t, test.notSupported, agentCommandNotSupported(err))
+		})
+	}
+}
+
+func Test_AgentOsInfo_mapToSDK(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output *AgentOsInfo
+	}{
+		{name: `Empty`,
+			input:  map[string]interface{}{},
+			output: &AgentOsInfo{}},
+		{name: `Full`,
+			input: map[string]interface{}{"result": map[string]interface{}{
+				"id":             "debian",
+				"name":           "Debian GNU/Linux",
+				"pretty-name":    "Debian GNU/Linux 12 (bookworm)",
+				"version":        "12 (bookworm)",
+				"version-id":     "12",
+				"variant":        "server",
+				"variant-id":     "srv",
+				"kernel-release": "6.1.0-18-amd64",
+				"kernel-version": "#1 SMP PREEMPT_DYNAMIC Debian 6.1.76-1",
+				"machine":        "x86_64"}},
+			output: &AgentOsInfo{
+				ID:            "debian",
+				Name:          "Debian GNU/Linux",
+				PrettyName:    "Debian GNU/Linux 12 (bookworm)",
+				Version:       "12 (bookworm)",
+				VersionID:     "12",
+				Variant:       "server",
+				VariantID:     "srv",
+				KernelRelease: "6.1.0-18-amd64",
+				KernelVersion: "#1 SMP PREEMPT_DYNAMIC Debian 6.1.76-1",
+				Machine:       "x86_64"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, AgentOsInfo{}.mapToSDK(test.input))
+		})
+	}
+}
+
+func Test_AgentUser_mapToSDK(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output []AgentUser
+	}{
+		{name: `Empty`,
+			input: map[string]interface{}{"result": []interface{}{}}},
+		{name: `Full`,
+			input: map[string]interface{}{"result": []interface{}{
+				map[string]interface{}{"user": "root", "login-time": float64(1700000000.5)},
+				map[string]interface{}{"user": "Administrator", "domain": "CORP", "login-time": float64(1700000100)}}},
+			output: []AgentUser{
+				{Name: "root", LoginTime: time.Unix(1700000000, 500000000)},
+				{Name: "Administrator", Domain: "CORP", LoginTime: time.Unix(1700000100, 0)}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, AgentUser{}.mapToSDK(test.input))
+		})
+	}
+}
+
+func Test_AgentTimezone_mapToSDK(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output *AgentTimezone
+	}{
+		{name: `Offset only`,
+			input:  map[string]interface{}{"result": map[string]interface{}{"offset": float64(-18000)}},
+			output: &AgentTimezone{Offset: -18000}},
+		{name: `Full`,
+			input:  map[string]interface{}{"result": map[string]interface{}{"zone": "CET", "offset": float64(3600)}},
+			output: &AgentTimezone{Zone: "CET", Offset: 3600}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, AgentTimezone{}.mapToSDK(test.input))
+		})
+	}
+}
+
+func Test_AgentVCpu_mapToSDK(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output []AgentVCpu
+	}{
+		{name: `Empty`,
+			input: map[string]interface{}{}},
+		{name: `Full`,
+			input: map[string]interface{}{"result": []interface{}{
+				map[string]interface{}{"logical-id": float64(0), "online": true, "can-offline": false},
+				map[string]interface{}{"logical-id": float64(1), "online": float64(0), "can-offline": float64(1)}}},
+			output: []AgentVCpu{
+				{LogicalID: 0, Online: true},
+				{LogicalID: 1, CanOffline: true}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, AgentVCpu{}.mapToSDK(test.input))
+		})
+	}
+}
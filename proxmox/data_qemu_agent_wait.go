@@ -0,0 +1,105 @@
+package proxmox
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"time"
+)
+
+const (
+	AgentWait_Error_Agent string = "guest agent did not respond before the deadline"
+	AgentWait_Error_IP    string = "guest did not report a matching ip address before the deadline"
+)
+
+// Filters the ip addresses reported by the guest agent.
+// Loopback, link-local and unspecified addresses are never matched.
+type AgentIpFilter struct {
+	Interface  string           `json:"interface,omitempty"`   // only match addresses of the interface with this name
+	MacAddress net.HardwareAddr `json:"mac_address,omitempty"` // only match addresses of the interface with this mac address
+	IPv4       bool             `json:"ipv4,omitempty"`        // match IPv4 addresses, when IPv4 and IPv6 are both false any address is matched
+	IPv6       bool             `json:"ipv6,omitempty"`        // match IPv6 addresses, when IPv4 and IPv6 are both false any address is matched
+}
+
+// Returns the addresses of the interfaces that match the filter.
+func (filter AgentIpFilter) match(interfaces []AgentNetworkInterface) (addresses []net.IP) {
+	for _, iFace := range interfaces {
+		if filter.Interface != "" && iFace.Name != filter.Interface {
+			continue
+		}
+		if len(filter.MacAddress) != 0 && !bytes.Equal(iFace.MacAddress, filter.MacAddress) {
+			continue
+		}
+		for _, ip := range iFace.IpAddresses {
+			if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() {
+				continue
+			}
+			if filter.IPv4 || filter.IPv6 {
+				isIPv4 := ip.To4() != nil
+				if (isIPv4 && !filter.IPv4) || (!isIPv4 && !filter.IPv6) {
+					continue
+				}
+			}
+			addresses = append(addresses, ip)
+		}
+	}
+	return
+}
+
+// Waits until the guest agent responds to a ping, checking every interval until the deadline has passed.
+// When interval is 0 the agent is checked every TaskStatusCheckInterval seconds.
+func (vmr *VmRef) WaitForAgent(c *Client, deadline time.Time, interval time.Duration) error {
+	if c == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return err
+	}
+	return vmr.waitForAgent(c, deadline, interval)
+}
+
+func (vmr *VmRef) waitForAgent(c *Client, deadline time.Time, interval time.Duration) error {
+	ready, err := waitUntil(deadline, interval, func() (bool, error) {
+		_, err := vmr.agentPost(c, "ping", nil)
+		return err == nil, err
+	})
+	if ready {
+		return nil
+	}
+	if err != nil {
+		return errors.New(AgentWait_Error_Agent + ", last error: " + err.Error())
+	}
+	return errors.New(AgentWait_Error_Agent)
+}
+
+// Waits until the guest agent is ready and reports an ip address matching the filter,
+// checking every interval until the deadline has passed.
+// When interval is 0 the guest is checked every TaskStatusCheckInterval seconds.
+// Returns all addresses matching the filter.
+func (vmr *VmRef) WaitForIP(c *Client, filter AgentIpFilter, deadline time.Time, interval time.Duration) ([]net.IP, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if err := c.CheckVmRef(vmr); err != nil {
+		return nil, err
+	}
+	if err := vmr.waitForAgent(c, deadline, interval); err != nil {
+		return nil, err
+	}
+	var addresses []net.IP
+	found, err := waitUntil(deadline, interval, func() (bool, error) {
+		interfaces, err := vmr.GetAgentInformation(c, false)
+		if err != nil {
+			return false, err
+		}
+		addresses = filter.match(interfaces)
+		return len(addresses) > 0, nil
+	})
+	if found {
+		return addresses, nil
+	}
+	if err != nil {
+		return nil, errors.New(AgentWait_Error_IP + ", last error: " + err.Error())
+	}
+	return nil, errors.New(AgentWait_Error_IP)
+}
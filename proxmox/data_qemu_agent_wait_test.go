@@ -0,0 +1,77 @@
+package proxmox
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AgentIpFilter_match(t *testing.T) {
+	parseMAC := func(mac string) net.HardwareAddr {
+		parsedMac, _ := net.ParseMAC(mac)
+		return parsedMac
+	}
+	interfaces := []AgentNetworkInterface{
+		{Name: "lo",
+			MacAddress: parseMAC("00:00:00:00:00:00"),
+			IpAddresses: []net.IP{
+				net.ParseIP("127.0.0.1"),
+				net.ParseIP("::1")}},
+		{Name: "eth0",
+			MacAddress: parseMAC("54:1a:12:8f:7b:ed"),
+			IpAddresses: []net.IP{
+				net.ParseIP("192.168.1.10"),
+				net.ParseIP("fe80::561a:12ff:fe8f:7bed"),
+				net.ParseIP("2001:db8::10")}},
+		{Name: "eth1",
+			MacAddress: parseMAC("1a:2b:3c:4d:5e:6f"),
+			IpAddresses: []net.IP{
+				net.ParseIP("169.254.10.1"),
+				net.ParseIP("10.0.0.5")}},
+		{Name: "eth2",
+			MacAddress: parseMAC("7a:b1:8f:2e:4d:6c")},
+	}
+	tests := []struct {
+		name   string
+		filter AgentIpFilter
+		output []net.IP
+	}{
+		{name: `No filter`,
+			output: []net.IP{
+				net.ParseIP("192.168.1.10"),
+				net.ParseIP("2001:db8::10"),
+				net.ParseIP("10.0.0.5")}},
+		{name: `IPv4`,
+			filter: AgentIpFilter{IPv4: true},
+			output: []net.IP{
+				net.ParseIP("192.168.1.10"),
+				net.ParseIP("10.0.0.5")}},
+		{name: `IPv6`,
+			filter: AgentIpFilter{IPv6: true},
+			output: []net.IP{net.ParseIP("2001:db8::10")}},
+		{name: `IPv4 and IPv6`,
+			filter: AgentIpFilter{IPv4: true, IPv6: true},
+			output: []net.IP{
+				net.ParseIP("192.168.1.10"),
+				net.ParseIP("2001:db8::10"),
+				net.ParseIP("10.0.0.5")}},
+		{name: `Interface`,
+			filter: AgentIpFilter{Interface: "eth1"},
+			output: []net.IP{net.ParseIP("10.0.0.5")}},
+		{name: `MacAddress`,
+			filter: AgentIpFilter{MacAddress: parseMAC("54:1a:12:8f:7b:ed"), IPv6: true},
+			output: []net.IP{net.ParseIP("2001:db8::10")}},
+		{name: `Interface and MacAddress mismatch`,
+			filter: AgentIpFilter{Interface: "eth1", MacAddress: parseMAC("54:1a:12:8f:7b:ed")}},
+		{name: `Loopback only`,
+			filter: AgentIpFilter{Interface: "lo"}},
+		{name: `No addresses`,
+			filter: AgentIpFilter{Interface: "eth2"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.filter.match(interfaces))
+		})
+	}
+}
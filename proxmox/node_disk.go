@@ -0,0 +1,271 @@
+package proxmox
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Telmate/proxmox-api-go/internal/parse"
+)
+
+// Physical disk or partition of a node.
+type NodeDisk struct {
+	ByIdLink string       `json:"by_id_link,omitempty"`
+	DevPath  string       `json:"devpath"` // e.g. "/dev/sda"
+	GPT      bool         `json:"gpt"`
+	Health   string       `json:"health,omitempty"` // e.g. "PASSED", "OK" or "UNKNOWN"
+	Model    string       `json:"model,omitempty"`
+	Mounted  bool         `json:"mounted"`
+	Parent   string       `json:"parent,omitempty"` // the disk of the partition
+	RPM      *uint        `json:"rpm,omitempty"`    // 0 for solid state disks, nil when unknown
+	Serial   string       `json:"serial,omitempty"`
+	Size     uint         `json:"size"` // in bytes
+	Type     NodeDiskType `json:"type"`
+	Used     string       `json:"used,omitempty"` // what the disk is used for, e.g. "LVM", "ZFS" or "partitions", empty when unused
+	Vendor   string       `json:"vendor,omitempty"`
+	Wearout  *uint        `json:"wearout,omitempty"` // remaining life in percent, nil when unknown
+	WWN      string       `json:"wwn,omitempty"`
+}
+
+func (NodeDisk) mapToSDK(params map[string]interface{}) (disk NodeDisk) {
+	if v, isSet := params["by_id_link"]; isSet {
+		disk.ByIdLink = v.(string)
+	}
+	if v, isSet := params["devpath"]; isSet {
+		disk.DevPath = v.(string)
+	}
+	if v, isSet := params["gpt"]; isSet {
+		disk.GPT = apiBool(v)
+	}
+	if v, isSet := params["health"]; isSet {
+		disk.Health = v.(string)
+	}
+	if v, isSet := params["model"]; isSet {
+		disk.Model = v.(string)
+	}
+	if v, isSet := params["mounted"]; isSet {
+		disk.Mounted = apiBool(v)
+	}
+	if v, isSet := params["parent"]; isSet {
+		disk.Parent = v.(string)
+	}
+	if v, isSet := params["rpm"]; isSet {
+		// -1 when the rpm is unknown
+		if rpm, err := parse.Uint(v); err == nil {
+			disk.RPM = &rpm
+		}
+	}
+	if v, isSet := params["serial"]; isSet {
+		disk.Serial = v.(string)
+	}
+	if v, isSet := params["size"]; isSet {
+		disk.Size, _ = parse.Uint(v)
+	}
+	if v, isSet := params["type"]; isSet {
+		disk.Type = NodeDiskType(v.(string))
+	}
+	if v, isSet := params["used"]; isSet {
+		disk.Used = v.(string)
+	}
+	if v, isSet := params["vendor"]; isSet {
+		disk.Vendor = strings.TrimSpace(v.(string))
+	}
+	if v, isSet := params["wearout"]; isSet {
+		// "N/A" when the wearout is unknown
+		if wearout, err := parse.Uint(v); err == nil {
+			disk.Wearout = &wearout
+		}
+	}
+	if v, isSet := params["wwn"]; isSet {
+		disk.WWN = v.(string)
+	}
+	return
+}
+
+// Returns true when the disk is not in use and has no partitions.
+func (disk NodeDisk) Unused() bool {
+	return disk.Used == "" && !disk.Mounted
+}
+
+type NodeDiskType string
+
+const (
+	NodeDiskType_HDD       NodeDiskType = "hdd"
+	NodeDiskType_NVME      NodeDiskType = "nvme"
+	NodeDiskType_Partition NodeDiskType = "partition"
+	NodeDiskType_SSD       NodeDiskType = "ssd"
+	NodeDiskType_USB       NodeDiskType = "usb"
+	NodeDiskType_Unknown   NodeDiskType = "unknown"
+)
+
+// Limits the disks that are listed, the zero value lists all disks without their partitions.
+type NodeDiskFilter struct {
+	IncludePartitions bool `json:"include_partitions,omitempty"`
+	SkipSmart         bool `json:"skip_smart,omitempty"` // skips the health and wearout, which is faster
+	Unused            bool `json:"unused,omitempty"`     // only list disks that are not in use
+}
+
+func (filter NodeDiskFilter) mapToApiValues() url.Values {
+	params := url.Values{}
+	if filter.IncludePartitions {
+		params.Set("include-partitions", "1")
+	}
+	if filter.SkipSmart {
+		params.Set("skipsmart", "1")
+	}
+	if filter.Unused {
+		params.Set("type", "unused")
+	}
+	return params
+}
+
+// List the disks of the node that match the filter, sorted by device path.
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/disks/list
+func ListNodeDisks(c *Client, node string, filter NodeDiskFilter) ([]NodeDisk, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	query := ""
+	if params := filter.mapToApiValues(); len(params) > 0 {
+		query = "?" + params.Encode()
+	}
+	list, err := c.GetItemListInterfaceArray("/nodes/" + node + "/disks/list" + query)
+	if err != nil {
+		return nil, err
+	}
+	disks := make([]NodeDisk, len(list))
+	for i, e := range list {
+		disks[i] = NodeDisk{}.mapToSDK(e.(map[string]interface{}))
+	}
+	sort.Slice(disks, func(i, j int) bool { return disks[i].DevPath < disks[j].DevPath })
+	return disks, nil
+}
+
+// SMART data of a disk.
+type NodeDiskSmart struct {
+	Attributes []NodeDiskSmartAttribute `json:"attributes,omitempty"` // only reported by ATA disks
+	Health     string                   `json:"health"`
+	Text       string                   `json:"text,omitempty"` // the raw smartctl output, reported by disks that do not use attributes e.g. NVMe
+}
+
+func (NodeDiskSmart) mapToSDK(params map[string]interface{}) (smart NodeDiskSmart) {
+	if v, isSet := params["attributes"]; isSet {
+		if attributes, ok := v.([]interface{}); ok {
+			smart.Attributes = make([]NodeDiskSmartAttribute, len(attributes))
+			for i, e := range attributes {
+				smart.Attributes[i] = NodeDiskSmartAttribute{}.mapToSDK(e.(map[string]interface{}))
+			}
+		}
+	}
+	if v, isSet := params["health"]; isSet {
+		smart.Health = v.(string)
+	}
+	if v, isSet := params["text"]; isSet {
+		smart.Text = v.(string)
+	}
+	return
+}
+
+type NodeDiskSmartAttribute struct {
+	Fail      string `json:"fail,omitempty"` // when the attribute failed, e.g. "FAILING_NOW" or "In_the_past"
+	Flags     string `json:"flags"`
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	Raw       string `json:"raw"`
+	Threshold uint   `json:"threshold"`
+	Value     uint   `json:"value"`
+	Worst     uint   `json:"worst"`
+}
+
+func (NodeDiskSmartAttribute) mapToSDK(params map[string]interface{}) (attribute NodeDiskSmartAttribute) {
+	if v, isSet := params["fail"]; isSet {
+		if fail := v.(string); fail != "-" {
+			attribute.Fail = fail
+		}
+	}
+	if v, isSet := params["flags"]; isSet {
+		attribute.Flags = v.(string)
+	}
+	if v, isSet := params["id"]; isSet {
+		if id, ok := v.(string); ok {
+			v = strings.TrimSpace(id)
+		}
+		attribute.ID, _ = parse.Uint(v)
+	}
+	if v, isSet := params["name"]; isSet {
+		attribute.Name = v.(string)
+	}
+	if v, isSet := params["raw"]; isSet {
+		switch raw := v.(type) {
+		case string:
+			attribute.Raw = raw
+		case float64:
+			attribute.Raw = strconv.FormatFloat(raw, 'f', -1, 64)
+		}
+	}
+	if v, isSet := params["threshold"]; isSet {
+		attribute.Threshold, _ = parse.Uint(v)
+	}
+	if v, isSet := params["value"]; isSet {
+		attribute.Value, _ = parse.Uint(v)
+	}
+	if v, isSet := params["worst"]; isSet {
+		attribute.Worst, _ = parse.Uint(v)
+	}
+	return
+}
+
+// Returns the SMART data of the disk, when healthOnly is true only the health is returned.
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/disks/smart
+func GetNodeDiskSmart(c *Client, node, disk string, healthOnly bool) (*NodeDiskSmart, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	params := url.Values{"disk": []string{disk}}
+	if healthOnly {
+		params.Set("healthonly", "1")
+	}
+	data, err := c.GetItemConfigMapStringInterface("/nodes/"+node+"/disks/smart?"+params.Encode(), "disk", "SMART")
+	if err != nil {
+		return nil, err
+	}
+	smart := NodeDiskSmart{}.mapToSDK(data)
+	return &smart, nil
+}
+
+const NodeDisk_Error_DevPath string = "disk should be a device path starting with /dev/"
+
+func validateNodeDiskDevPath(disk string) error {
+	if !strings.HasPrefix(disk, "/dev/") || len(disk) == len("/dev/") {
+		return errors.New(NodeDisk_Error_DevPath)
+	}
+	return nil
+}
+
+// Initializes the disk with a new GPT partition table, the disk should be unused.
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/disks/initgpt
+func InitializeNodeDiskGPT(c *Client, node, disk string) error {
+	if c == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	if err := validateNodeDiskDevPath(disk); err != nil {
+		return err
+	}
+	_, err := c.PostWithTask(map[string]interface{}{"disk": disk}, "/nodes/"+node+"/disks/initgpt")
+	return err
+}
+
+// Wipes the partition table and signatures of the disk or partition, all data on it is lost.
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/disks/wipedisk
+func WipeNodeDisk(c *Client, node, disk string) error {
+	if c == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	if err := validateNodeDiskDevPath(disk); err != nil {
+		return err
+	}
+	_, err := c.PutWithTask(map[string]interface{}{"disk": disk}, "/nodes/"+node+"/disks/wipedisk")
+	return err
+}
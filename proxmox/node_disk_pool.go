@@ -0,0 +1,322 @@
+package proxmox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const NodeDiskPool_Error_Name string = "name should start with a letter, end with a letter or number and only contain letters, numbers, '-', '_' and '.'"
+
+var regex_NodeDiskPoolName = regexp.MustCompile(`^[a-zA-Z]([a-zA-Z0-9\-_.]*[a-zA-Z0-9])?$`)
+
+func validateNodeDiskPoolName(name string) error {
+	if !regex_NodeDiskPoolName.MatchString(name) {
+		return errors.New(NodeDiskPool_Error_Name)
+	}
+	return nil
+}
+
+func validateNodeDiskPool(node, name, device string) error {
+	if node == "" {
+		return ErrorKeyEmpty("node")
+	}
+	if err := validateNodeDiskPoolName(name); err != nil {
+		return err
+	}
+	return validateNodeDiskDevPath(device)
+}
+
+func createNodeDiskPool(c *Client, params map[string]interface{}, node, kind string) error {
+	if c == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	if _, err := c.PostWithTask(params, "/nodes/"+node+"/disks/"+kind); err != nil {
+		params, _ := json.Marshal(&params)
+		return fmt.Errorf("error creating %s on disk: %v, (params: %v)", kind, err, string(params))
+	}
+	return nil
+}
+
+// LVM volume group to create on an unused disk.
+type ConfigNodeDisk_LVM struct {
+	AddStorage bool   `json:"add_storage"` // also add a storage with the same name as the volume group
+	Device     string `json:"device"`      // e.g. "/dev/sdb"
+	Name       string `json:"name"`        // name of the volume group
+	Node       string `json:"node"`
+}
+
+func (config ConfigNodeDisk_LVM) mapToApiValues() map[string]interface{} {
+	return map[string]interface{}{
+		"add_storage": Btoi(config.AddStorage),
+		"device":      config.Device,
+		"name":        config.Name,
+	}
+}
+
+// Returns the storage config of the created volume group, for use in ConfigStorage.LVM.
+func (config ConfigNodeDisk_LVM) StorageConfig() ConfigStorageLVM {
+	return ConfigStorageLVM{VGname: config.Name}
+}
+
+func (config ConfigNodeDisk_LVM) Validate() error {
+	return validateNodeDiskPool(config.Node, config.Name, config.Device)
+}
+
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/disks/lvm
+func (config ConfigNodeDisk_LVM) Create(c *Client) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return config.Create_Unsafe(c)
+}
+
+// Create the volume group without validating the input, use ConfigNodeDisk_LVM.Create() to validate the input.
+func (config ConfigNodeDisk_LVM) Create_Unsafe(c *Client) error {
+	return createNodeDiskPool(c, config.mapToApiValues(), config.Node, "lvm")
+}
+
+// LVM thin pool to create on an unused disk, the volume group and thin pool both get the same name.
+type ConfigNodeDisk_LVMThin struct {
+	AddStorage bool   `json:"add_storage"` // also add a storage with the same name as the thin pool
+	Device     string `json:"device"`      // e.g. "/dev/sdb"
+	Name       string `json:"name"`        // name of the volume group and thin pool
+	Node       string `json:"node"`
+}
+
+func (config ConfigNodeDisk_LVMThin) mapToApiValues() map[string]interface{} {
+	return map[string]interface{}{
+		"add_storage": Btoi(config.AddStorage),
+		"device":      config.Device,
+		"name":        config.Name,
+	}
+}
+
+// Returns the storage config of the created thin pool, for use in ConfigStorage.LVMThin.
+func (config ConfigNodeDisk_LVMThin) StorageConfig() ConfigStorageLVMThin {
+	return ConfigStorageLVMThin{VGname: config.Name, Thinpool: config.Name}
+}
+
+func (config ConfigNodeDisk_LVMThin) Validate() error {
+	return validateNodeDiskPool(config.Node, config.Name, config.Device)
+}
+
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/disks/lvmthin
+func (config ConfigNodeDisk_LVMThin) Create(c *Client) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return config.Create_Unsafe(c)
+}
+
+// Create the thin pool without validating the input, use ConfigNodeDisk_LVMThin.Create() to validate the input.
+func (config ConfigNodeDisk_LVMThin) Create_Unsafe(c *Client) error {
+	return createNodeDiskPool(c, config.mapToApiValues(), config.Node, "lvmthin")
+}
+
+// Directory to create on an unused disk, the disk is formatted and mounted on /mnt/pve/<name>.
+type ConfigNodeDisk_Directory struct {
+	AddStorage bool               `json:"add_storage"` // also add a storage with the same name as the directory
+	Device     string             `json:"device"`      // e.g. "/dev/sdb"
+	Filesystem NodeDiskFilesystem `json:"filesystem,omitempty"`
+	Name       string             `json:"name"`
+	Node       string             `json:"node"`
+}
+
+func (config ConfigNodeDisk_Directory) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{
+		"add_storage": Btoi(config.AddStorage),
+		"device":      config.Device,
+		"name":        config.Name,
+	}
+	if config.Filesystem != "" {
+		params["filesystem"] = string(config.Filesystem)
+	}
+	return params
+}
+
+// Returns the storage config of the created directory, for use in ConfigStorage.Directory.
+func (config ConfigNodeDisk_Directory) StorageConfig() ConfigStorageDirectory {
+	return ConfigStorageDirectory{Path: "/mnt/pve/" + config.Name}
+}
+
+func (config ConfigNodeDisk_Directory) Validate() error {
+	if err := validateNodeDiskPool(config.Node, config.Name, config.Device); err != nil {
+		return err
+	}
+	return config.Filesystem.Validate()
+}
+
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/disks/directory
+func (config ConfigNodeDisk_Directory) Create(c *Client) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return config.Create_Unsafe(c)
+}
+
+// Create the directory without validating the input, use ConfigNodeDisk_Directory.Create() to validate the input.
+func (config ConfigNodeDisk_Directory) Create_Unsafe(c *Client) error {
+	return createNodeDiskPool(c, config.mapToApiValues(), config.Node, "directory")
+}
+
+type NodeDiskFilesystem string
+
+const (
+	NodeDiskFilesystem_Ext4 NodeDiskFilesystem = "ext4"
+	NodeDiskFilesystem_Xfs  NodeDiskFilesystem = "xfs"
+)
+
+const NodeDiskFilesystem_Error_Invalid string = "filesystem should be one of [ext4, xfs]"
+
+func (filesystem NodeDiskFilesystem) Validate() error {
+	switch filesystem {
+	case "", NodeDiskFilesystem_Ext4, NodeDiskFilesystem_Xfs:
+		return nil
+	}
+	return errors.New(NodeDiskFilesystem_Error_Invalid)
+}
+
+// ZFS pool to create on one or more unused disks.
+type ConfigNodeDisk_ZFS struct {
+	AddStorage  bool                   `json:"add_storage"` // also add a storage with the same name as the pool
+	Ashift      *uint                  `json:"ashift,omitempty"`
+	Compression NodeDiskZfsCompression `json:"compression,omitempty"`
+	Devices     []string               `json:"devices"` // e.g. ["/dev/sdb", "/dev/sdc"]
+	Name        string                 `json:"name"`
+	Node        string                 `json:"node"`
+	RaidLevel   NodeDiskZfsRaidLevel   `json:"raid_level"`
+}
+
+const (
+	ConfigNodeDisk_ZFS_Error_Ashift  string = "ashift should be in the range 9-16"
+	ConfigNodeDisk_ZFS_Error_Devices string = "the number of devices is not supported by the raid level"
+)
+
+func (config ConfigNodeDisk_ZFS) mapToApiValues() map[string]interface{} {
+	params := map[string]interface{}{
+		"add_storage": Btoi(config.AddStorage),
+		"devices":     strings.Join(config.Devices, ","),
+		"name":        config.Name,
+		"raidlevel":   string(config.RaidLevel),
+	}
+	if config.Ashift != nil {
+		params["ashift"] = int(*config.Ashift)
+	}
+	if config.Compression != "" {
+		params["compression"] = string(config.Compression)
+	}
+	return params
+}
+
+// Returns the storage config of the created pool, for use in ConfigStorage.ZFS.
+func (config ConfigNodeDisk_ZFS) StorageConfig() ConfigStorageZFS {
+	return ConfigStorageZFS{Pool: config.Name}
+}
+
+func (config ConfigNodeDisk_ZFS) Validate() error {
+	if config.Node == "" {
+		return ErrorKeyEmpty("node")
+	}
+	if err := validateNodeDiskPoolName(config.Name); err != nil {
+		return err
+	}
+	for _, e := range config.Devices {
+		if err := validateNodeDiskDevPath(e); err != nil {
+			return err
+		}
+	}
+	if err := config.RaidLevel.Validate(); err != nil {
+		return err
+	}
+	if !config.RaidLevel.devicesSupported(len(config.Devices)) {
+		return errors.New(ConfigNodeDisk_ZFS_Error_Devices)
+	}
+	if config.Ashift != nil && (*config.Ashift < 9 || *config.Ashift > 16) {
+		return errors.New(ConfigNodeDisk_ZFS_Error_Ashift)
+	}
+	return config.Compression.Validate()
+}
+
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/disks/zfs
+func (config ConfigNodeDisk_ZFS) Create(c *Client) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	return config.Create_Unsafe(c)
+}
+
+// Create the pool without validating the input, use ConfigNodeDisk_ZFS.Create() to validate the input.
+func (config ConfigNodeDisk_ZFS) Create_Unsafe(c *Client) error {
+	return createNodeDiskPool(c, config.mapToApiValues(), config.Node, "zfs")
+}
+
+type NodeDiskZfsCompression string
+
+const (
+	NodeDiskZfsCompression_Gzip NodeDiskZfsCompression = "gzip"
+	NodeDiskZfsCompression_Lz4  NodeDiskZfsCompression = "lz4"
+	NodeDiskZfsCompression_Lzjb NodeDiskZfsCompression = "lzjb"
+	NodeDiskZfsCompression_Off  NodeDiskZfsCompression = "off"
+	NodeDiskZfsCompression_On   NodeDiskZfsCompression = "on"
+	NodeDiskZfsCompression_Zle  NodeDiskZfsCompression = "zle"
+	NodeDiskZfsCompression_Zstd NodeDiskZfsCompression = "zstd"
+)
+
+const NodeDiskZfsCompression_Error_Invalid string = "compression should be one of [gzip, lz4, lzjb, off, on, zle, zstd]"
+
+func (compression NodeDiskZfsCompression) Validate() error {
+	switch compression {
+	case "", NodeDiskZfsCompression_Gzip, NodeDiskZfsCompression_Lz4, NodeDiskZfsCompression_Lzjb, NodeDiskZfsCompression_Off, NodeDiskZfsCompression_On, NodeDiskZfsCompression_Zle, NodeDiskZfsCompression_Zstd:
+		return nil
+	}
+	return errors.New(NodeDiskZfsCompression_Error_Invalid)
+}
+
+type NodeDiskZfsRaidLevel string
+
+const (
+	NodeDiskZfsRaidLevel_DRaid  NodeDiskZfsRaidLevel = "draid"
+	NodeDiskZfsRaidLevel_DRaid2 NodeDiskZfsRaidLevel = "draid2"
+	NodeDiskZfsRaidLevel_DRaid3 NodeDiskZfsRaidLevel = "draid3"
+	NodeDiskZfsRaidLevel_Mirror NodeDiskZfsRaidLevel = "mirror"
+	NodeDiskZfsRaidLevel_Raid10 NodeDiskZfsRaidLevel = "raid10"
+	NodeDiskZfsRaidLevel_RaidZ  NodeDiskZfsRaidLevel = "raidz"
+	NodeDiskZfsRaidLevel_RaidZ2 NodeDiskZfsRaidLevel = "raidz2"
+	NodeDiskZfsRaidLevel_RaidZ3 NodeDiskZfsRaidLevel = "raidz3"
+	NodeDiskZfsRaidLevel_Single NodeDiskZfsRaidLevel = "single"
+)
+
+const NodeDiskZfsRaidLevel_Error_Invalid string = "raid level should be one of [draid, draid2, draid3, mirror, raid10, raidz, raidz2, raidz3, single]"
+
+func (level NodeDiskZfsRaidLevel) Validate() error {
+	switch level {
+	case NodeDiskZfsRaidLevel_DRaid, NodeDiskZfsRaidLevel_DRaid2, NodeDiskZfsRaidLevel_DRaid3,
+		NodeDiskZfsRaidLevel_Mirror, NodeDiskZfsRaidLevel_Raid10, NodeDiskZfsRaidLevel_RaidZ, NodeDiskZfsRaidLevel_RaidZ2, NodeDiskZfsRaidLevel_RaidZ3, NodeDiskZfsRaidLevel_Single:
+		return nil
+	}
+	return errors.New(NodeDiskZfsRaidLevel_Error_Invalid)
+}
+
+func (level NodeDiskZfsRaidLevel) devicesSupported(devices int) bool {
+	minimum := map[NodeDiskZfsRaidLevel]int{
+		NodeDiskZfsRaidLevel_DRaid:  3,
+		NodeDiskZfsRaidLevel_DRaid2: 4,
+		NodeDiskZfsRaidLevel_DRaid3: 5,
+		NodeDiskZfsRaidLevel_Mirror: 2,
+		NodeDiskZfsRaidLevel_Raid10: 4,
+		NodeDiskZfsRaidLevel_RaidZ:  3,
+		NodeDiskZfsRaidLevel_RaidZ2: 4,
+		NodeDiskZfsRaidLevel_RaidZ3: 5,
+		NodeDiskZfsRaidLevel_Single: 1,
+	}
+	switch level {
+	case NodeDiskZfsRaidLevel_Single:
+		return devices == 1
+	case NodeDiskZfsRaidLevel_Raid10:
+		return devices >= minimum[level] && devices%2 == 0
+	}
+	return devices >= minimum[level]
+}
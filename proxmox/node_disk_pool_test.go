@@ -0,0 +1,124 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConfigNodeDisk_mapToApiValues(t *testing.T) {
+	require.Equal(t,
+		map[string]interface{}{"add_storage": 1, "device": "/dev/sdb", "name": "vg1"},
+		ConfigNodeDisk_LVM{AddStorage: true, Device: "/dev/sdb", Name: "vg1", Node: "pve"}.mapToApiValues())
+	require.Equal(t,
+		map[string]interface{}{"add_storage": 0, "device": "/dev/sdb", "name": "thin1"},
+		ConfigNodeDisk_LVMThin{Device: "/dev/sdb", Name: "thin1", Node: "pve"}.mapToApiValues())
+	require.Equal(t,
+		map[string]interface{}{"add_storage": 1, "device": "/dev/sdc", "filesystem": "xfs", "name": "data"},
+		ConfigNodeDisk_Directory{AddStorage: true, Device: "/dev/sdc", Filesystem: NodeDiskFilesystem_Xfs, Name: "data", Node: "pve"}.mapToApiValues())
+	require.Equal(t,
+		map[string]interface{}{"add_storage": 1, "ashift": 12, "compression": "lz4", "devices": "/dev/sdb,/dev/sdc", "name": "tank", "raidlevel": "mirror"},
+		ConfigNodeDisk_ZFS{
+			AddStorage:  true,
+			Ashift:      util.Pointer(uint(12)),
+			Compression: NodeDiskZfsCompression_Lz4,
+			Devices:     []string{"/dev/sdb", "/dev/sdc"},
+			Name:        "tank",
+			Node:        "pve",
+			RaidLevel:   NodeDiskZfsRaidLevel_Mirror}.mapToApiValues())
+}
+
+func Test_ConfigNodeDisk_StorageConfig(t *testing.T) {
+	require.Equal(t, ConfigStorageLVM{VGname: "vg1"}, ConfigNodeDisk_LVM{Name: "vg1"}.StorageConfig())
+	require.Equal(t, ConfigStorageLVMThin{VGname: "thin1", Thinpool: "thin1"}, ConfigNodeDisk_LVMThin{Name: "thin1"}.StorageConfig())
+	require.Equal(t, ConfigStorageDirectory{Path: "/mnt/pve/data"}, ConfigNodeDisk_Directory{Name: "data"}.StorageConfig())
+	require.Equal(t, ConfigStorageZFS{Pool: "tank"}, ConfigNodeDisk_ZFS{Name: "tank"}.StorageConfig())
+}
+
+func Test_ConfigNodeDisk_Directory_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  ConfigNodeDisk_Directory
+		output error
+	}{
+		{name: `Valid`,
+			input: ConfigNodeDisk_Directory{Device: "/dev/sdb", Name: "data-1", Node: "pve"}},
+		{name: `Invalid Node`,
+			input:  ConfigNodeDisk_Directory{Device: "/dev/sdb", Name: "data"},
+			output: ErrorKeyEmpty("node")},
+		{name: `Invalid Name`,
+			input:  ConfigNodeDisk_Directory{Device: "/dev/sdb", Name: "1data", Node: "pve"},
+			output: errors.New(NodeDiskPool_Error_Name)},
+		{name: `Invalid Name end`,
+			input:  ConfigNodeDisk_Directory{Device: "/dev/sdb", Name: "data-", Node: "pve"},
+			output: errors.New(NodeDiskPool_Error_Name)},
+		{name: `Invalid Device`,
+			input:  ConfigNodeDisk_Directory{Device: "sdb", Name: "data", Node: "pve"},
+			output: errors.New(NodeDisk_Error_DevPath)},
+		{name: `Invalid Filesystem`,
+			input:  ConfigNodeDisk_Directory{Device: "/dev/sdb", Filesystem: "btrfs", Name: "data", Node: "pve"},
+			output: errors.New(NodeDiskFilesystem_Error_Invalid)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
+
+func Test_ConfigNodeDisk_ZFS_Validate(t *testing.T) {
+	devices := func(amount int) []string {
+		list := make([]string, amount)
+		for i := range list {
+			list[i] = "/dev/sd" + string(rune('b'+i))
+		}
+		return list
+	}
+	tests := []struct {
+		name   string
+		input  ConfigNodeDisk_ZFS
+		output error
+	}{
+		{name: `Valid Single`,
+			input: ConfigNodeDisk_ZFS{Devices: devices(1), Name: "tank", Node: "pve", RaidLevel: NodeDiskZfsRaidLevel_Single}},
+		{name: `Valid Raid10`,
+			input: ConfigNodeDisk_ZFS{Devices: devices(6), Name: "tank", Node: "pve", RaidLevel: NodeDiskZfsRaidLevel_Raid10}},
+		{name: `Valid RaidZ2`,
+			input: ConfigNodeDisk_ZFS{Ashift: util.Pointer(uint(12)), Compression: NodeDiskZfsCompression_Zstd, Devices: devices(4), Name: "tank", Node: "pve", RaidLevel: NodeDiskZfsRaidLevel_RaidZ2}},
+		{name: `Invalid Single devices`,
+			input:  ConfigNodeDisk_ZFS{Devices: devices(2), Name: "tank", Node: "pve", RaidLevel: NodeDiskZfsRaidLevel_Single},
+			output: errors.New(ConfigNodeDisk_ZFS_Error_Devices)},
+		{name: `Invalid Raid10 uneven`,
+			input:  ConfigNodeDisk_ZFS{Devices: devices(5), Name: "tank", Node: "pve", RaidLevel: NodeDiskZfsRaidLevel_Raid10},
+			output: errors.New(ConfigNodeDisk_ZFS_Error_Devices)},
+		{name: `Invalid RaidZ3 devices`,
+			input:  ConfigNodeDisk_ZFS{Devices: devices(4), Name: "tank", Node: "pve", RaidLevel: NodeDiskZfsRaidLevel_RaidZ3},
+			output: errors.New(ConfigNodeDisk_ZFS_Error_Devices)},
+		{name: `Valid dRAID`,
+			input: ConfigNodeDisk_ZFS{Devices: devices(3), Name: "tank", Node: "pve", RaidLevel: NodeDiskZfsRaidLevel_DRaid}},
+		{name: `Valid dRAID3`,
+			input: ConfigNodeDisk_ZFS{Devices: devices(8), Name: "tank", Node: "pve", RaidLevel: NodeDiskZfsRaidLevel_DRaid3}},
+		{name: `Invalid dRAID2 devices`,
+			input:  ConfigNodeDisk_ZFS{Devices: devices(3), Name: "tank", Node: "pve", RaidLevel: NodeDiskZfsRaidLevel_DRaid2},
+			output: errors.New(ConfigNodeDisk_ZFS_Error_Devices)},
+		{name: `Invalid RaidLevel`,
+			input:  ConfigNodeDisk_ZFS{Devices: devices(2), Name: "tank", Node: "pve", RaidLevel: "raid5"},
+			output: errors.New(NodeDiskZfsRaidLevel_Error_Invalid)},
+		{name: `Invalid Device`,
+			input:  ConfigNodeDisk_ZFS{Devices: []string{"/dev/sdb", "sdc"}, Name: "tank", Node: "pve", RaidLevel: NodeDiskZfsRaidLevel_Mirror},
+			output: errors.New(NodeDisk_Error_DevPath)},
+		{name: `Invalid Ashift`,
+			input:  ConfigNodeDisk_ZFS{Ashift: util.Pointer(uint(17)), Devices: devices(1), Name: "tank", Node: "pve", RaidLevel: NodeDiskZfsRaidLevel_Single},
+			output: errors.New(ConfigNodeDisk_ZFS_Error_Ashift)},
+		{name: `Invalid Compression`,
+			input:  ConfigNodeDisk_ZFS{Compression: "brotli", Devices: devices(1), Name: "tank", Node: "pve", RaidLevel: NodeDiskZfsRaidLevel_Single},
+			output: errors.New(NodeDiskZfsCompression_Error_Invalid)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package proxmox
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NodeDisk_mapToSDK(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output NodeDisk
+	}{
+		{name: `SSD`,
+			input: map[string]interface{}{
+				"by_id_link": "/dev/disk/by-id/ata-Samsung_SSD_870_S1",
+				"devpath":    "/dev/sda",
+				"gpt":        float64(1),
+				"health":     "PASSED",
+				"model":      "Samsung_SSD_870",
+				"mounted":    float64(0),
+				"osdid":      float64(-1),
+				"rpm":        float64(0),
+				"serial":     "S1",
+				"size":       float64(1000204886016),
+				"type":       "ssd",
+				"used":       "LVM",
+				"vendor":     "ATA     ",
+				"wearout":    float64(98),
+				"wwn":        "0x5002538f4"},
+			output: NodeDisk{
+				ByIdLink: "/dev/disk/by-id/ata-Samsung_SSD_870_S1",
+				DevPath:  "/dev/sda",
+				GPT:      true,
+				Health:   "PASSED",
+				Model:    "Samsung_SSD_870",
+				RPM:      util.Pointer(uint(0)),
+				Serial:   "S1",
+				Size:     1000204886016,
+				Type:     NodeDiskType_SSD,
+				Used:     "LVM",
+				Vendor:   "ATA",
+				Wearout:  util.Pointer(uint(98)),
+				WWN:      "0x5002538f4"}},
+		{name: `HDD`,
+			input: map[string]interface{}{
+				"devpath": "/dev/sdc",
+				"rpm":     float64(7200),
+				"type":    "hdd"},
+			output: NodeDisk{
+				DevPath: "/dev/sdc",
+				RPM:     util.Pointer(uint(7200)),
+				Type:    NodeDiskType_HDD}},
+		{name: `Unknown wearout and rpm`,
+			input: map[string]interface{}{
+				"devpath": "/dev/sdb",
+				"gpt":     float64(0),
+				"rpm":     float64(-1),
+				"size":    float64(4000787030016),
+				"type":    "hdd",
+				"wearout": "N/A"},
+			output: NodeDisk{
+				DevPath: "/dev/sdb",
+				Size:    4000787030016,
+				Type:    NodeDiskType_HDD}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, NodeDisk{}.mapToSDK(test.input))
+		})
+	}
+}
+
+func Test_NodeDisk_Unused(t *testing.T) {
+	require.True(t, NodeDisk{}.Unused())
+	require.False(t, NodeDisk{Used: "partitions"}.Unused())
+	require.False(t, NodeDisk{Mounted: true}.Unused())
+}
+
+func Test_NodeDiskFilter_mapToApiValues(t *testing.T) {
+	require.Equal(t, url.Values{}, NodeDiskFilter{}.mapToApiValues())
+	require.Equal(t,
+		url.Values{"include-partitions": []string{"1"}, "skipsmart": []string{"1"}, "type": []string{"unused"}},
+		NodeDiskFilter{IncludePartitions: true, SkipSmart: true, Unused: true}.mapToApiValues())
+}
+
+func Test_NodeDiskSmart_mapToSDK(t *testing.T) {
+	require.Equal(t,
+		NodeDiskSmart{
+			Attributes: []NodeDiskSmartAttribute{
+				{Flags: "PO--CK", ID: 5, Name: "Reallocated_Sector_Ct", Raw: "0", Threshold: 10, Value: 100, Worst: 100},
+				{Fail: "FAILING_NOW", Flags: "-O--CK", ID: 177, Name: "Wear_Leveling_Count", Raw: "1520", Threshold: 5, Value: 4, Worst: 4}},
+			Health: "FAILED"},
+		NodeDiskSmart{}.mapToSDK(map[string]interface{}{
+			"attributes": []interface{}{
+				map[string]interface{}{"fail": "-", "flags": "PO--CK", "id": "  5", "name": "Reallocated_Sector_Ct", "raw": "0", "threshold": "010", "value": "100", "worst": "100"},
+				map[string]interface{}{"fail": "FAILING_NOW", "flags": "-O--CK", "id": "177", "name": "Wear_Leveling_Count", "raw": float64(1520), "threshold": float64(5), "value": float64(4), "worst": float64(4)}},
+			"health": "FAILED",
+			"type":   "ata"}))
+	require.Equal(t,
+		NodeDiskSmart{Health: "PASSED", Text: "Critical Warning: 0x00"},
+		NodeDiskSmart{}.mapToSDK(map[string]interface{}{"health": "PASSED", "text": "Critical Warning: 0x00", "type": "text"}))
+}
+
+func Test_validateNodeDiskDevPath(t *testing.T) {
+	require.NoError(t, validateNodeDiskDevPath("/dev/sda"))
+	require.NoError(t, validateNodeDiskDevPath("/dev/nvme0n1"))
+	require.Equal(t, errors.New(NodeDisk_Error_DevPath), validateNodeDiskDevPath("sda"))
+	require.Equal(t, errors.New(NodeDisk_Error_DevPath), validateNodeDiskDevPath("/dev/"))
+}
@@ -0,0 +1,238 @@
+package proxmox
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// List the network interfaces of the node, sorted by name.
+// When the node has pending changes the pending configuration is returned.
+// When filter is empty all interfaces are listed.
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/network
+func ListNetworkInterfaces(c *Client, node string, filter NetworkInterfaceType) ([]ConfigNetwork, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	url := "/nodes/" + node + "/network"
+	if filter != "" {
+		if err := filter.Validate(); err != nil {
+			return nil, err
+		}
+		url += "?type=" + string(filter)
+	}
+	list, err := c.GetItemListInterfaceArray(url)
+	if err != nil {
+		return nil, err
+	}
+	interfaces := make([]ConfigNetwork, len(list))
+	for i, e := range list {
+		interfaces[i] = ConfigNetwork{}.mapToSDK(e.(map[string]interface{}))
+		interfaces[i].Node = node
+	}
+	sort.Slice(interfaces, func(i, j int) bool { return interfaces[i].Iface < interfaces[j].Iface })
+	return interfaces, nil
+}
+
+func NewConfigNetworkFromApi(c *Client, node, iface string) (*ConfigNetwork, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	if err := validateNetworkIface(iface); err != nil {
+		return nil, err
+	}
+	params, err := c.GetItemConfigMapStringInterface("/nodes/"+node+"/network/"+iface, "network interface", "CONFIG")
+	if err != nil {
+		return nil, err
+	}
+	config := ConfigNetwork{}.mapToSDK(params)
+	config.Iface = iface
+	config.Node = node
+	return &config, nil
+}
+
+// Unified diff between /etc/network/interfaces and the pending /etc/network/interfaces.new of a node.
+type NetworkChanges string
+
+// Returns the names of the interfaces that have pending changes, sorted by name.
+func (changes NetworkChanges) Interfaces() []string {
+	var current string
+	changed := map[string]struct{}{}
+	for _, line := range strings.Split(string(changes), "\n") {
+		if line == "" || strings.HasPrefix(line, "---") || strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "@@") {
+			continue
+		}
+		prefix, content := line[0], strings.Fields(line[1:])
+		if len(content) > 1 {
+			switch content[0] {
+			case "auto", "iface":
+				current = content[1]
+			default:
+				if strings.HasPrefix(content[0], "allow-") {
+					current = content[1]
+				}
+			}
+		}
+		if (prefix == '+' || prefix == '-') && current != "" {
+			changed[current] = struct{}{}
+		}
+	}
+	interfaces := make([]string, 0, len(changed))
+	for iface := range changed {
+		interfaces = append(interfaces, iface)
+	}
+	sort.Strings(interfaces)
+	return interfaces
+}
+
+// Returns true when there are changes that have not been applied yet.
+func (changes NetworkChanges) Pending() bool {
+	return strings.TrimSpace(string(changes)) != ""
+}
+
+// Returns the pending changes to the network configuration of the node, empty when there are no pending changes.
+func GetNetworkChanges(c *Client, node string) (NetworkChanges, error) {
+	if c == nil {
+		return "", errors.New(Client_Error_Nil)
+	}
+	list, err := c.GetItemList("/nodes/" + node + "/network")
+	if err != nil {
+		return "", err
+	}
+	// the diff is returned next to the data when /etc/network/interfaces.new exists
+	if changes, isSet := list["changes"].(string); isSet {
+		return NetworkChanges(changes), nil
+	}
+	return "", nil
+}
+
+const (
+	ApplyNetwork_Error_PendingChanges string = "node has pending network changes, apply or revert them first"
+	ApplyNetwork_Error_StageNil       string = "stage may not be nil"
+)
+
+// Changes the network configuration of the node and applies it, restoring the previous configuration when anything fails.
+// stage makes the changes, e.g. through ConfigNetwork.CreateNetwork() and ConfigNetwork.UpdateNetwork(), these stay pending until applied.
+// check is called after the changes are applied, e.g. to verify the node and its guests are still reachable.
+// When stage fails the pending changes are reverted.
+// When applying or check fails the configuration from before stage is written back and applied.
+// The node may not have pending changes beforehand, as the configuration that is running could not be read otherwise.
+func ApplyNetworkWithRevert(c *Client, node string, stage func() error, check func() error) error {
+	if stage == nil {
+		return errors.New(ApplyNetwork_Error_StageNil)
+	}
+	changes, err := GetNetworkChanges(c, node)
+	if err != nil {
+		return err
+	}
+	if changes.Pending() {
+		return errors.New(ApplyNetwork_Error_PendingChanges)
+	}
+	snapshot, err := ListNetworkInterfaces(c, node, "")
+	if err != nil {
+		return err
+	}
+	if err = stage(); err != nil {
+		if _, revertErr := c.RevertNetwork(node); revertErr != nil {
+			return fmt.Errorf("error reverting network changes: %v, after staging failed: %v", revertErr, err)
+		}
+		return fmt.Errorf("error staging network changes, changes reverted: %v", err)
+	}
+	if _, err = c.ApplyNetwork(node); err != nil {
+		err = fmt.Errorf("error applying network changes: %v", err)
+	} else if check != nil {
+		err = check()
+	}
+	if err == nil {
+		return nil
+	}
+	if restoreErr := restoreNetwork(c, node, snapshot); restoreErr != nil {
+		return fmt.Errorf("error restoring network configuration: %v, after: %v", restoreErr, err)
+	}
+	return fmt.Errorf("network configuration restored: %v", err)
+}
+
+// Writes the interfaces in the snapshot back to the node and applies them.
+func restoreNetwork(c *Client, node string, snapshot []ConfigNetwork) error {
+	// an apply that failed may have left the changes pending
+	if _, err := c.RevertNetwork(node); err != nil {
+		return err
+	}
+	running, err := ListNetworkInterfaces(c, node, "")
+	if err != nil {
+		return err
+	}
+	changes := networkRestoreChanges(snapshot, running)
+	for _, iface := range changes.delete {
+		if _, err = c.DeleteNetwork(node, iface); err != nil {
+			return err
+		}
+	}
+	for _, e := range changes.update {
+		if _, err = c.UpdateNetwork(node, e.iface, e.params); err != nil {
+			return err
+		}
+	}
+	for _, params := range changes.create {
+		if _, err = c.CreateNetwork(node, params); err != nil {
+			return err
+		}
+	}
+	_, err = c.ApplyNetwork(node)
+	return err
+}
+
+// The requests that turn the running interfaces back into a snapshot.
+type networkRestore struct {
+	create []map[string]interface{}
+	delete []string // interfaces
+	update []networkRestoreUpdate
+}
+
+type networkRestoreUpdate struct {
+	iface  string
+	params map[string]interface{}
+}
+
+// Interfaces that are not in the snapshot are deleted, except physical ones.
+// Settings that are only set on the running interface are added to the "delete" list of the update.
+func networkRestoreChanges(snapshot, running []ConfigNetwork) (changes networkRestore) {
+	current := make(map[string]ConfigNetwork, len(running))
+	for _, e := range running {
+		current[e.Iface] = e
+	}
+	kept := make(map[string]struct{}, len(snapshot))
+	for _, e := range snapshot {
+		kept[e.Iface] = struct{}{}
+	}
+	for _, e := range running {
+		if _, isSet := kept[e.Iface]; !isSet && e.Type != NetworkInterfaceType_Eth {
+			changes.delete = append(changes.delete, e.Iface)
+		}
+	}
+	for _, config := range snapshot {
+		old, isSet := current[config.Iface]
+		if !isSet {
+			changes.create = append(changes.create, config.mapToApiValues())
+			continue
+		}
+		if reflect.DeepEqual(old, config) {
+			continue
+		}
+		params := config.mapToApiValues()
+		deletions := make([]string, 0)
+		for key := range old.mapToApiValues() {
+			if _, isSet := params[key]; !isSet {
+				deletions = append(deletions, key)
+			}
+		}
+		if len(deletions) > 0 {
+			sort.Strings(deletions)
+			params["delete"] = strings.Join(deletions, ",")
+		}
+		changes.update = append(changes.update, networkRestoreUpdate{iface: config.Iface, params: params})
+	}
+	return
+}
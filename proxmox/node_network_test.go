@@ -0,0 +1,131 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ApplyNetworkWithRevert(t *testing.T) {
+	require.Equal(t, errors.New(ApplyNetwork_Error_StageNil), ApplyNetworkWithRevert(nil, "pve", nil, nil))
+	require.Equal(t, errors.New(Client_Error_Nil), ApplyNetworkWithRevert(nil, "pve", func() error { return nil }, nil))
+}
+
+func Test_networkRestoreChanges(t *testing.T) {
+	eno1 := ConfigNetwork{Iface: "eno1", Type: NetworkInterfaceType_Eth}
+	eno2 := ConfigNetwork{Iface: "eno2", Type: NetworkInterfaceType_Eth}
+	vmbr0 := ConfigNetwork{Iface: "vmbr0", Type: NetworkInterfaceType_Bridge, BridgePorts: "eno1", CIDR: "10.0.0.10/24"}
+	vmbr1 := ConfigNetwork{Iface: "vmbr1", Type: NetworkInterfaceType_Bridge}
+	vmbr2 := ConfigNetwork{Iface: "vmbr2", Type: NetworkInterfaceType_Bridge, Autostart: true, MTU: 9000}
+	type testInput struct {
+		snapshot []ConfigNetwork
+		running  []ConfigNetwork
+	}
+	tests := []struct {
+		name   string
+		input  testInput
+		output networkRestore
+	}{
+		{name: `Unchanged`,
+			input: testInput{
+				snapshot: []ConfigNetwork{eno1, vmbr0},
+				running:  []ConfigNetwork{eno1, vmbr0}}},
+		{name: `Delete added interface`,
+			input: testInput{
+				snapshot: []ConfigNetwork{eno1, vmbr0},
+				running:  []ConfigNetwork{eno1, vmbr0, vmbr1}},
+			output: networkRestore{delete: []string{"vmbr1"}}},
+		{name: `Physical interface is not deleted`,
+			input: testInput{
+				snapshot: []ConfigNetwork{eno1, vmbr0},
+				running:  []ConfigNetwork{eno1, eno2, vmbr0}}},
+		{name: `Create removed interface`,
+			input: testInput{
+				snapshot: []ConfigNetwork{eno1, vmbr0, vmbr2},
+				running:  []ConfigNetwork{eno1, vmbr0}},
+			output: networkRestore{create: []map[string]interface{}{
+				{"iface": "vmbr2", "type": "bridge", "autostart": true, "mtu": float64(9000)}}}},
+		{name: `Update changed interface`,
+			input: testInput{
+				snapshot: []ConfigNetwork{eno1, vmbr0},
+				running:  []ConfigNetwork{eno1, {Iface: "vmbr0", Type: NetworkInterfaceType_Bridge, BridgePorts: "eno1", CIDR: "10.0.0.11/24"}}},
+			output: networkRestore{update: []networkRestoreUpdate{
+				{iface: "vmbr0", params: map[string]interface{}{"iface": "vmbr0", "type": "bridge", "bridge_ports": "eno1", "cidr": "10.0.0.10/24"}}}}},
+		{name: `Update deletes added settings`,
+			input: testInput{
+				snapshot: []ConfigNetwork{eno1, vmbr0},
+				running:  []ConfigNetwork{eno1, {Iface: "vmbr0", Type: NetworkInterfaceType_Bridge, BridgePorts: "eno1", CIDR: "10.0.0.10/24", Gateway: "10.0.0.1", MTU: 1500}}},
+			output: networkRestore{update: []networkRestoreUpdate{
+				{iface: "vmbr0", params: map[string]interface{}{"iface": "vmbr0", "type": "bridge", "bridge_ports": "eno1", "cidr": "10.0.0.10/24", "delete": "gateway,mtu"}}}}},
+		{name: `All changes`,
+			input: testInput{
+				snapshot: []ConfigNetwork{eno1, vmbr0, vmbr2},
+				running:  []ConfigNetwork{eno1, {Iface: "vmbr0", Type: NetworkInterfaceType_Bridge, BridgePorts: "eno1", CIDR: "10.0.0.11/24", Gateway: "10.0.0.1"}, vmbr1}},
+			output: networkRestore{
+				create: []map[string]interface{}{
+					{"iface": "vmbr2", "type": "bridge", "autostart": true, "mtu": float64(9000)}},
+				delete: []string{"vmbr1"},
+				update: []networkRestoreUpdate{
+					{iface: "vmbr0", params: map[string]interface{}{"iface": "vmbr0", "type": "bridge", "bridge_ports": "eno1", "cidr": "10.0.0.10/24", "delete": "gateway"}}}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, networkRestoreChanges(test.input.snapshot, test.input.running))
+		})
+	}
+}
+
+func Test_NetworkChanges_Interfaces(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  NetworkChanges
+		output []string
+	}{
+		{name: `Empty`,
+			output: []string{}},
+		{name: `Changed address`,
+			input: `--- /etc/network/interfaces	2024-01-01 00:00:00.000000000 +0000
++++ /etc/network/interfaces.new	2024-01-01 00:00:00.000000000 +0000
+@@ -5,7 +5,7 @@
+ auto vmbr0
+ iface vmbr0 inet static
+-	address 192.168.1.10/24
++	address 192.168.1.11/24
+ 	gateway 192.168.1.1
+ 	bridge-ports eno1
+ 	bridge-stp off
+`,
+			output: []string{"vmbr0"}},
+		{name: `Added interfaces`,
+			input: `--- /etc/network/interfaces	2024-01-01 00:00:00.000000000 +0000
++++ /etc/network/interfaces.new	2024-01-01 00:00:00.000000000 +0000
+@@ -10,4 +10,16 @@
+ 	bridge-stp off
+ 	bridge-fd 0
+ 
++auto bond0
++iface bond0 inet manual
++	bond-slaves eno2 eno3
++	bond-mode 802.3ad
++
++auto vmbr1
++iface vmbr1 inet manual
++	bridge-ports bond0
++
+ source /etc/network/interfaces.d/*
+`,
+			output: []string{"bond0", "vmbr1"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Interfaces())
+		})
+	}
+}
+
+func Test_NetworkChanges_Pending(t *testing.T) {
+	require.False(t, NetworkChanges("").Pending())
+	require.False(t, NetworkChanges("\n").Pending())
+	require.True(t, NetworkChanges("+auto vmbr1").Pending())
+}
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -354,6 +355,40 @@ func (s *Session) Get(
 	return s.Request("GET", url, params, headers, nil)
 }
 
+// Perform a get to an endpoint and copy the response body into w without buffering it.
+func (s *Session) GetStream(
+	url string,
+	params *url.Values,
+	headers *http.Header,
+	w io.Writer,
+) error {
+	url = s.ApiUrl + url
+	if params != nil {
+		url = url + "?" + params.Encode()
+	}
+	req, err := s.NewRequest("GET", url, headers, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range s.Headers {
+		req.Header[k] = v
+	}
+	if *Debug {
+		d, _ := httputil.DumpRequestOut(req, false)
+		log.Printf(">>>>>>>>>> REQUEST:\n%v", string(d))
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.New(resp.Status)
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
 func (s *Session) GetJSON(
 	url string,
 	params *url.Values,
@@ -0,0 +1,195 @@
+package proxmox
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/fs"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// A backup snapshot on a Proxmox Backup Server storage of which individual files can be restored.
+type PbsFileRestore struct {
+	Node     string `json:"node"`     // node that connects to the Proxmox Backup Server
+	Storage  string `json:"storage"`  // id of the Proxmox Backup Server storage
+	Snapshot string `json:"snapshot"` // e.g. "vm/100/2024-05-01T10:00:00Z"
+}
+
+const (
+	PbsFileRestore_Error_NodeEmpty     string = "node may not be empty"
+	PbsFileRestore_Error_Path          string = "path should be absolute"
+	PbsFileRestore_Error_SnapshotEmpty string = "snapshot may not be empty"
+	PbsFileRestore_Error_StorageEmpty  string = "storage may not be empty"
+	PbsFileRestore_Error_VolumeId      string = "volume id is not a backup on a Proxmox Backup Server: "
+)
+
+// Creates a PbsFileRestore from the volume id of a backup, e.g. "pbs:backup/vm/100/2024-05-01T10:00:00Z".
+func NewPbsFileRestore(node, volumeId string) (*PbsFileRestore, error) {
+	archive, err := NewBackupArchive(volumeId)
+	if err != nil || !archive.Pbs {
+		return nil, errors.New(PbsFileRestore_Error_VolumeId + volumeId)
+	}
+	storage, snapshot, _ := strings.Cut(volumeId, ":backup/")
+	return &PbsFileRestore{Node: node, Storage: storage, Snapshot: snapshot}, nil
+}
+
+func (restore PbsFileRestore) Validate() error {
+	if restore.Node == "" {
+		return errors.New(PbsFileRestore_Error_NodeEmpty)
+	}
+	if restore.Storage == "" {
+		return errors.New(PbsFileRestore_Error_StorageEmpty)
+	}
+	if restore.Snapshot == "" {
+		return errors.New(PbsFileRestore_Error_SnapshotEmpty)
+	}
+	return nil
+}
+
+func (restore PbsFileRestore) url() string {
+	return "/nodes/" + restore.Node + "/storage/" + restore.Storage + "/file-restore/"
+}
+
+func (restore PbsFileRestore) params(path string) url.Values {
+	return url.Values{
+		"snapshot": []string{restore.Snapshot},
+		"filepath": []string{base64.StdEncoding.EncodeToString([]byte(path))},
+	}
+}
+
+func (restore PbsFileRestore) validate(c *Client, path string) error {
+	if c == nil {
+		return errors.New(Client_Error_Nil)
+	}
+	if err := restore.Validate(); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(path, "/") {
+		return errors.New(PbsFileRestore_Error_Path)
+	}
+	return nil
+}
+
+// Lists the entries of the directory at path, the root of the backup is "/".
+// The root contains the archives of the backup, e.g. "/drive-scsi0.img.fidx", which in turn contain the partitions of the disk.
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/storage/{storage}/file-restore/list
+func (restore PbsFileRestore) List(c *Client, path string) ([]PbsFileRestoreEntry, error) {
+	if err := restore.validate(c, path); err != nil {
+		return nil, err
+	}
+	list, err := c.GetItemListInterfaceArray(restore.url() + "list?" + restore.params(path).Encode())
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]PbsFileRestoreEntry, len(list))
+	for i, e := range list {
+		entries[i] = PbsFileRestoreEntry{}.mapToSDK(e.(map[string]interface{}))
+	}
+	return entries, nil
+}
+
+// Walks the directory tree starting at path, calling fn for every entry.
+// When fn returns fs.SkipDir for a directory its contents are skipped, any other error stops the walk and is returned.
+// Listing large backups for the first time can take a while, as the Proxmox Backup Server has to mount the archives.
+func (restore PbsFileRestore) Walk(c *Client, path string, fn func(entry PbsFileRestoreEntry) error) error {
+	entries, err := restore.List(c, path)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err = fn(e); err != nil {
+			if errors.Is(err, fs.SkipDir) {
+				continue
+			}
+			return err
+		}
+		if e.Leaf {
+			continue
+		}
+		if err = restore.Walk(c, e.Path, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Downloads the file at path into w.
+// When path is a directory it is downloaded as a zip archive, or as a zstd compressed tar archive when tar is true.
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/storage/{storage}/file-restore/download
+func (restore PbsFileRestore) Download(c *Client, path string, tar bool, w io.Writer) error {
+	if err := restore.validate(c, path); err != nil {
+		return err
+	}
+	params := restore.params(path)
+	if tar {
+		params.Set("tar", "1")
+	}
+	return c.session.GetStream(restore.url()+"download", &params, nil, w)
+}
+
+// An entry in the directory tree of a backup.
+type PbsFileRestoreEntry struct {
+	Leaf         bool                    `json:"leaf"` // the entry has no children
+	ModifiedTime *time.Time              `json:"modified,omitempty"`
+	Name         string                  `json:"name"`
+	Path         string                  `json:"path"`
+	Size         uint                    `json:"size"`
+	Type         PbsFileRestoreEntryType `json:"type"`
+}
+
+func (PbsFileRestoreEntry) mapToSDK(params map[string]interface{}) (entry PbsFileRestoreEntry) {
+	if v, isSet := params["filepath"]; isSet {
+		if path, err := base64.StdEncoding.DecodeString(v.(string)); err == nil {
+			entry.Path = string(path)
+		}
+	}
+	if v, isSet := params["leaf"]; isSet {
+		entry.Leaf = apiBool(v)
+	}
+	if v, isSet := params["mtime"]; isSet {
+		if mtime, ok := v.(float64); ok {
+			modified := time.Unix(int64(mtime), 0)
+			entry.ModifiedTime = &modified
+		}
+	}
+	if v, isSet := params["size"]; isSet {
+		if size, ok := v.(float64); ok {
+			entry.Size = uint(size)
+		}
+	}
+	if v, isSet := params["text"]; isSet {
+		entry.Name = v.(string)
+	}
+	if v, isSet := params["type"]; isSet {
+		entry.Type = PbsFileRestoreEntryType(v.(string))
+	}
+	return
+}
+
+type PbsFileRestoreEntryType string
+
+const (
+	PbsFileRestoreEntryType_Directory PbsFileRestoreEntryType = "d"
+	PbsFileRestoreEntryType_File      PbsFileRestoreEntryType = "f"
+	PbsFileRestoreEntryType_HardLink  PbsFileRestoreEntryType = "h"
+	PbsFileRestoreEntryType_SymLink   PbsFileRestoreEntryType = "l"
+	PbsFileRestoreEntryType_Virtual   PbsFileRestoreEntryType = "v" // archives and partitions
+)
+
+func (entryType PbsFileRestoreEntryType) String() string { // String is for fmt.Stringer.
+	switch entryType {
+	case PbsFileRestoreEntryType_Directory:
+		return "directory"
+	case PbsFileRestoreEntryType_File:
+		return "file"
+	case PbsFileRestoreEntryType_HardLink:
+		return "hardlink"
+	case PbsFileRestoreEntryType_SymLink:
+		return "symlink"
+	case PbsFileRestoreEntryType_Virtual:
+		return "virtual"
+	}
+	return string(entryType)
+}
@@ -0,0 +1,122 @@
+package proxmox
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewPbsFileRestore(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		output *PbsFileRestore
+		err    error
+	}{
+		{name: `Valid vm`,
+			input:  "pbs:backup/vm/100/2024-05-01T10:00:00Z",
+			output: &PbsFileRestore{Node: "pve", Storage: "pbs", Snapshot: "vm/100/2024-05-01T10:00:00Z"}},
+		{name: `Valid ct`,
+			input:  "backup-server:backup/ct/101/2024-05-01T10:00:00Z",
+			output: &PbsFileRestore{Node: "pve", Storage: "backup-server", Snapshot: "ct/101/2024-05-01T10:00:00Z"}},
+		{name: `Invalid directory storage`,
+			input: "local:backup/vzdump-qemu-100-2024_05_01-10_00_00.vma.zst",
+			err:   errors.New(PbsFileRestore_Error_VolumeId + "local:backup/vzdump-qemu-100-2024_05_01-10_00_00.vma.zst")},
+		{name: `Invalid volume`,
+			input: "local:iso/debian.iso",
+			err:   errors.New(PbsFileRestore_Error_VolumeId + "local:iso/debian.iso")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			restore, err := NewPbsFileRestore("pve", test.input)
+			require.Equal(t, test.err, err)
+			require.Equal(t, test.output, restore)
+		})
+	}
+}
+
+func Test_PbsFileRestore_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  PbsFileRestore
+		output error
+	}{
+		{name: `Valid`,
+			input: PbsFileRestore{Node: "pve", Storage: "pbs", Snapshot: "vm/100/2024-05-01T10:00:00Z"}},
+		{name: `Invalid Node`,
+			input:  PbsFileRestore{Storage: "pbs", Snapshot: "vm/100/2024-05-01T10:00:00Z"},
+			output: errors.New(PbsFileRestore_Error_NodeEmpty)},
+		{name: `Invalid Storage`,
+			input:  PbsFileRestore{Node: "pve", Snapshot: "vm/100/2024-05-01T10:00:00Z"},
+			output: errors.New(PbsFileRestore_Error_StorageEmpty)},
+		{name: `Invalid Snapshot`,
+			input:  PbsFileRestore{Node: "pve", Storage: "pbs"},
+			output: errors.New(PbsFileRestore_Error_SnapshotEmpty)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.Validate())
+		})
+	}
+}
+
+func Test_PbsFileRestore_params(t *testing.T) {
+	restore := PbsFileRestore{Node: "pve", Storage: "pbs", Snapshot: "vm/100/2024-05-01T10:00:00Z"}
+	require.Equal(t, url.Values{
+		"snapshot": []string{"vm/100/2024-05-01T10:00:00Z"},
+		"filepath": []string{"L2RyaXZlLXNjc2kwLmltZy5maWR4L3BhcnQvMi9ldGM="}},
+		restore.params("/drive-scsi0.img.fidx/part/2/etc"))
+	require.Equal(t, "/nodes/pve/storage/pbs/file-restore/", restore.url())
+}
+
+func Test_PbsFileRestore_validate(t *testing.T) {
+	restore := PbsFileRestore{Node: "pve", Storage: "pbs", Snapshot: "vm/100/2024-05-01T10:00:00Z"}
+	require.Equal(t, errors.New(Client_Error_Nil), restore.validate(nil, "/"))
+	require.Equal(t, errors.New(PbsFileRestore_Error_Path), restore.validate(&Client{}, "etc"))
+	require.NoError(t, restore.validate(&Client{}, "/"))
+}
+
+func Test_PbsFileRestoreEntry_mapToSDK(t *testing.T) {
+	modified := time.Unix(1714557600, 0)
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output PbsFileRestoreEntry
+	}{
+		{name: `Archive`,
+			input: map[string]interface{}{
+				"filepath": "L2RyaXZlLXNjc2kwLmltZy5maWR4",
+				"leaf":     float64(0),
+				"size":     float64(34359738368),
+				"text":     "drive-scsi0.img.fidx",
+				"type":     "v"},
+			output: PbsFileRestoreEntry{
+				Name: "drive-scsi0.img.fidx",
+				Path: "/drive-scsi0.img.fidx",
+				Size: 34359738368,
+				Type: PbsFileRestoreEntryType_Virtual}},
+		{name: `File`,
+			input: map[string]interface{}{
+				"filepath": "L2RyaXZlLXNjc2kwLmltZy5maWR4L3BhcnQvMi9ldGMvaG9zdHM=",
+				"leaf":     true,
+				"mtime":    float64(1714557600),
+				"size":     float64(221),
+				"text":     "hosts",
+				"type":     "f"},
+			output: PbsFileRestoreEntry{
+				Leaf:         true,
+				ModifiedTime: &modified,
+				Name:         "hosts",
+				Path:         "/drive-scsi0.img.fidx/part/2/etc/hosts",
+				Size:         221,
+				Type:         PbsFileRestoreEntryType_File}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, PbsFileRestoreEntry{}.mapToSDK(test.input))
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package proxmox
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Telmate/proxmox-api-go/internal/parse"
+	"github.com/Telmate/proxmox-api-go/internal/util"
+)
+
+// Description of a guest that can be imported, e.g. from an ESXi storage or an OVA/OVF file.
+type ImportMetadata struct {
+	Bios           string                                           `json:"bios,omitempty"` // "seabios" or "ovmf"
+	Boot           string                                           `json:"boot,omitempty"` // e.g. "order=scsi0;net0"
+	Cores          uint                                             `json:"cores,omitempty"`
+	Disks          map[QemuDiskId]ImportMetadataDisk                `json:"disks,omitempty"`
+	MemoryMiB      uint                                             `json:"memory,omitempty"`
+	Name           string                                           `json:"name,omitempty"`
+	Networks       map[QemuNetworkInterfaceID]ImportMetadataNetwork `json:"networks,omitempty"`
+	OsType         string                                           `json:"ostype,omitempty"`
+	ScsiController string                                           `json:"scsihw,omitempty"`
+	Smbios1        string                                           `json:"smbios1,omitempty"`
+	Sockets        uint                                             `json:"sockets,omitempty"`
+	Source         string                                           `json:"source"`
+	Type           string                                           `json:"type"` // currently only "vm"
+	Warnings       []ImportMetadataWarning                          `json:"warnings,omitempty"`
+}
+
+// A disk of the imported guest.
+type ImportMetadataDisk struct {
+	SizeBytes uint   `json:"size,omitempty"`
+	Volume    string `json:"volume"` // volume id of the source disk, e.g. "esxi:ha-datacenter/datastore1/guest/guest.vmdk"
+}
+
+// A network interface of the imported guest.
+type ImportMetadataNetwork struct {
+	MacAddress string `json:"macaddr,omitempty"`
+	Model      string `json:"model"` // e.g. "vmxnet3" or "e1000"
+}
+
+// Something that could not be imported as is, e.g. an unsupported disk controller.
+type ImportMetadataWarning struct {
+	Key   string `json:"key,omitempty"`
+	Type  string `json:"type"`
+	Value string `json:"value,omitempty"`
+}
+
+func (ImportMetadata) mapToSDK(params map[string]interface{}) (metadata ImportMetadata) {
+	if v, isSet := params["create-args"]; isSet {
+		args := v.(map[string]interface{})
+		if v, isSet := args["bios"]; isSet {
+			metadata.Bios = v.(string)
+		}
+		if v, isSet := args["boot"]; isSet {
+			metadata.Boot = v.(string)
+		}
+		if v, isSet := args["cores"]; isSet {
+			metadata.Cores, _ = parse.Uint(v)
+		}
+		if v, isSet := args["memory"]; isSet {
+			metadata.MemoryMiB, _ = parse.Uint(v)
+		}
+		if v, isSet := args["name"]; isSet {
+			metadata.Name = v.(string)
+		}
+		if v, isSet := args["ostype"]; isSet {
+			metadata.OsType = v.(string)
+		}
+		if v, isSet := args["scsihw"]; isSet {
+			metadata.ScsiController = v.(string)
+		}
+		if v, isSet := args["smbios1"]; isSet {
+			metadata.Smbios1 = v.(string)
+		}
+		if v, isSet := args["sockets"]; isSet {
+			metadata.Sockets, _ = parse.Uint(v)
+		}
+	}
+	if v, isSet := params["disks"]; isSet {
+		disks := v.(map[string]interface{})
+		metadata.Disks = make(map[QemuDiskId]ImportMetadataDisk, len(disks))
+		for id, e := range disks {
+			var disk ImportMetadataDisk
+			settings := e.(map[string]interface{})
+			if v, isSet := settings["defined-size"]; isSet {
+				disk.SizeBytes, _ = parse.Uint(v)
+			}
+			if v, isSet := settings["volid"]; isSet {
+				disk.Volume = v.(string)
+			}
+			metadata.Disks[QemuDiskId(id)] = disk
+		}
+	}
+	if v, isSet := params["net"]; isSet {
+		networks := v.(map[string]interface{})
+		metadata.Networks = make(map[QemuNetworkInterfaceID]ImportMetadataNetwork, len(networks))
+		for id, e := range networks {
+			nicID, err := strconv.ParseUint(strings.TrimPrefix(id, "net"), 10, 8)
+			if err != nil {
+				continue
+			}
+			var network ImportMetadataNetwork
+			settings := e.(map[string]interface{})
+			if v, isSet := settings["macaddr"]; isSet {
+				network.MacAddress = v.(string)
+			}
+			if v, isSet := settings["model"]; isSet {
+				network.Model = v.(string)
+			}
+			metadata.Networks[QemuNetworkInterfaceID(nicID)] = network
+		}
+	}
+	if v, isSet := params["source"]; isSet {
+		metadata.Source = v.(string)
+	}
+	if v, isSet := params["type"]; isSet {
+		metadata.Type = v.(string)
+	}
+	if v, isSet := params["warnings"]; isSet {
+		warnings := v.([]interface{})
+		metadata.Warnings = make([]ImportMetadataWarning, len(warnings))
+		for i, e := range warnings {
+			settings := e.(map[string]interface{})
+			if v, isSet := settings["key"]; isSet {
+				metadata.Warnings[i].Key = v.(string)
+			}
+			if v, isSet := settings["type"]; isSet {
+				metadata.Warnings[i].Type = v.(string)
+			}
+			if v, isSet := settings["value"]; isSet {
+				metadata.Warnings[i].Value = v.(string)
+			}
+		}
+	}
+	return
+}
+
+// Where and how the guest described by ImportMetadata gets imported.
+type ImportMetadataTarget struct {
+	Bridge  string         `json:"bridge"`           // bridge all network interfaces are attached to, e.g. "vmbr0"
+	Format  QemuDiskFormat `json:"format,omitempty"` // when empty the default format of the storage is used
+	Storage string         `json:"storage"`          // storage the disks are imported to
+}
+
+const (
+	ImportMetadataTarget_Error_Bridge  string = "bridge may not be empty"
+	ImportMetadataTarget_Error_Storage string = "storage may not be empty"
+)
+
+func (target ImportMetadataTarget) Validate() error {
+	if target.Bridge == "" {
+		return errors.New(ImportMetadataTarget_Error_Bridge)
+	}
+	if target.Storage == "" {
+		return errors.New(ImportMetadataTarget_Error_Storage)
+	}
+	if target.Format != "" {
+		return target.Format.Validate()
+	}
+	return nil
+}
+
+// Converts the metadata to a draft that imports the disks and network interfaces of the guest.
+// The VmID, Node and Pool still have to be set on the draft before it can be created.
+// Disks on controllers that are not supported (e.g. NVMe) are skipped, these are also listed in the warnings.
+func (metadata ImportMetadata) ConfigQemu(target ImportMetadataTarget) (*ConfigQemu, error) {
+	if err := target.Validate(); err != nil {
+		return nil, err
+	}
+	config := ConfigQemu{
+		Bios:    metadata.Bios,
+		Boot:    metadata.Boot,
+		Name:    metadata.Name,
+		QemuOs:  metadata.OsType,
+		Scsihw:  metadata.ScsiController,
+		Smbios1: metadata.Smbios1,
+	}
+	if metadata.Cores != 0 || metadata.Sockets != 0 {
+		config.CPU = &QemuCPU{}
+		if metadata.Cores != 0 {
+			config.CPU.Cores = util.Pointer(QemuCpuCores(metadata.Cores))
+		}
+		if metadata.Sockets != 0 {
+			config.CPU.Sockets = util.Pointer(QemuCpuSockets(metadata.Sockets))
+		}
+	}
+	if metadata.MemoryMiB != 0 {
+		config.Memory = &QemuMemory{CapacityMiB: util.Pointer(QemuMemoryCapacity(metadata.MemoryMiB))}
+	}
+	if metadata.Bios == "ovmf" {
+		config.EFIDisk = QemuDevice{"storage": target.Storage, "efitype": "4m"}
+	}
+	if len(metadata.Disks) > 0 {
+		config.Disks = &QemuStorages{}
+		for id, disk := range metadata.Disks {
+			config.Disks.importDisk(id, QemuDiskImport{Volume: disk.Volume}, target)
+		}
+	}
+	if len(metadata.Networks) > 0 {
+		config.QemuNetworks = QemuDevices{}
+		for id, network := range metadata.Networks {
+			device := QemuDevice{"bridge": target.Bridge, "model": network.Model}
+			if network.MacAddress != "" {
+				device["macaddr"] = network.MacAddress
+			}
+			config.QemuNetworks[int(id)] = device
+		}
+	}
+	return &config, nil
+}
+
+// Adds a disk that is imported from the source, ids that are not valid are ignored.
+func (storages *QemuStorages) importDisk(id QemuDiskId, source QemuDiskImport, target ImportMetadataTarget) {
+	if id.Validate() != nil {
+		return
+	}
+	var bus string
+	var number uint64
+	for _, e := range []string{"ide", "sata", "scsi", "virtio"} {
+		if strings.HasPrefix(string(id), e) {
+			bus = e
+			number, _ = strconv.ParseUint(strings.TrimPrefix(string(id), e), 10, 8)
+			break
+		}
+	}
+	switch bus {
+	case "ide":
+		if storages.Ide == nil {
+			storages.Ide = &QemuIdeDisks{}
+		}
+		*storages.Ide.mapToIntMapPointer()[uint8(number)] = &QemuIdeStorage{Disk: &QemuIdeDisk{
+			Backup: true, Format: target.Format, ImportFrom: &source, Replicate: true, Storage: target.Storage}}
+	case "sata":
+		if storages.Sata == nil {
+			storages.Sata = &QemuSataDisks{}
+		}
+		*storages.Sata.mapToIntMapPointer()[uint8(number)] = &QemuSataStorage{Disk: &QemuSataDisk{
+			Backup: true, Format: target.Format, ImportFrom: &source, Replicate: true, Storage: target.Storage}}
+	case "scsi":
+		if storages.Scsi == nil {
+			storages.Scsi = &QemuScsiDisks{}
+		}
+		*storages.Scsi.mapToIntMapPointer()[uint8(number)] = &QemuScsiStorage{Disk: &QemuScsiDisk{
+			Backup: true, Format: target.Format, ImportFrom: &source, Replicate: true, Storage: target.Storage}}
+	case "virtio":
+		if storages.VirtIO == nil {
+			storages.VirtIO = &QemuVirtIODisks{}
+		}
+		*storages.VirtIO.mapToIntMapPointer()[uint8(number)] = &QemuVirtIOStorage{Disk: &QemuVirtIODisk{
+			Backup: true, Format: target.Format, ImportFrom: &source, Replicate: true, Storage: target.Storage}}
+	}
+}
+
+// Reads the metadata of a guest that can be imported, the volume should be of content type import.
+// e.g. "esxi:ha-datacenter/datastore1/guest/guest.vmx" or "local:import/guest.ova"
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/storage/{storage}/import-metadata
+func GetImportMetadata(c *Client, node, volumeId string) (*ImportMetadata, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	storage, _, _ := strings.Cut(volumeId, ":")
+	params, err := c.GetItemConfigMapStringInterface("/nodes/"+node+"/storage/"+storage+"/import-metadata?"+url.Values{"volume": []string{volumeId}}.Encode(), "import", "METADATA")
+	if err != nil {
+		return nil, err
+	}
+	metadata := ImportMetadata{}.mapToSDK(params)
+	return &metadata, nil
+}
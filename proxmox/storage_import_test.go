@@ -0,0 +1,126 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Telmate/proxmox-api-go/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ImportMetadata_mapToSDK(t *testing.T) {
+	require.Equal(t,
+		ImportMetadata{
+			Bios:  "ovmf",
+			Boot:  "order=scsi0;net0",
+			Cores: 2,
+			Disks: map[QemuDiskId]ImportMetadataDisk{
+				"scsi0": {SizeBytes: 34359738368, Volume: "esxi:ha-datacenter/datastore1/web/web.vmdk"},
+				"sata1": {SizeBytes: 1073741824, Volume: "esxi:ha-datacenter/datastore1/web/web_1.vmdk"}},
+			MemoryMiB: 4096,
+			Name:      "web",
+			Networks: map[QemuNetworkInterfaceID]ImportMetadataNetwork{
+				0: {MacAddress: "00:50:56:aa:bb:cc", Model: "vmxnet3"}},
+			OsType:         "l26",
+			ScsiController: "pvscsi",
+			Smbios1:        "uuid=42",
+			Sockets:        1,
+			Source:         "esxi:ha-datacenter/datastore1/web/web.vmx",
+			Type:           "vm",
+			Warnings:       []ImportMetadataWarning{{Key: "nvme0:0", Type: "nvme-unsupported"}}},
+		ImportMetadata{}.mapToSDK(map[string]interface{}{
+			"create-args": map[string]interface{}{
+				"bios":    "ovmf",
+				"boot":    "order=scsi0;net0",
+				"cores":   float64(2),
+				"memory":  float64(4096),
+				"name":    "web",
+				"ostype":  "l26",
+				"scsihw":  "pvscsi",
+				"smbios1": "uuid=42",
+				"sockets": float64(1)},
+			"disks": map[string]interface{}{
+				"scsi0": map[string]interface{}{"defined-size": float64(34359738368), "volid": "esxi:ha-datacenter/datastore1/web/web.vmdk"},
+				"sata1": map[string]interface{}{"defined-size": float64(1073741824), "volid": "esxi:ha-datacenter/datastore1/web/web_1.vmdk"}},
+			"net": map[string]interface{}{
+				"net0": map[string]interface{}{"macaddr": "00:50:56:aa:bb:cc", "model": "vmxnet3"}},
+			"source":   "esxi:ha-datacenter/datastore1/web/web.vmx",
+			"type":     "vm",
+			"warnings": []interface{}{map[string]interface{}{"key": "nvme0:0", "type": "nvme-unsupported"}}}))
+}
+
+func Test_ImportMetadataTarget_Validate(t *testing.T) {
+	require.NoError(t, ImportMetadataTarget{Bridge: "vmbr0", Storage: "local-lvm"}.Validate())
+	require.NoError(t, ImportMetadataTarget{Bridge: "vmbr0", Format: QemuDiskFormat_Qcow2, Storage: "local"}.Validate())
+	require.Equal(t, errors.New(ImportMetadataTarget_Error_Bridge), ImportMetadataTarget{Storage: "local"}.Validate())
+	require.Equal(t, errors.New(ImportMetadataTarget_Error_Storage), ImportMetadataTarget{Bridge: "vmbr0"}.Validate())
+	require.Equal(t, QemuDiskFormat("iso").Validate(), ImportMetadataTarget{Bridge: "vmbr0", Format: "iso", Storage: "local"}.Validate())
+}
+
+func Test_ImportMetadata_ConfigQemu(t *testing.T) {
+	target := ImportMetadataTarget{Bridge: "vmbr1", Format: QemuDiskFormat_Raw, Storage: "ceph"}
+	importDisk := func(volume string) QemuDiskImport { return QemuDiskImport{Volume: volume} }
+	tests := []struct {
+		name   string
+		input  ImportMetadata
+		target ImportMetadataTarget
+		output *ConfigQemu
+		err    error
+	}{
+		{name: `Full`,
+			input: ImportMetadata{
+				Bios:  "ovmf",
+				Boot:  "order=scsi0;net0",
+				Cores: 4,
+				Disks: map[QemuDiskId]ImportMetadataDisk{
+					"efidisk0": {Volume: "esxi:ha-datacenter/datastore1/web/web.nvram"},
+					"ide3":     {Volume: "esxi:ha-datacenter/datastore1/web/web_3.vmdk"},
+					"sata1":    {Volume: "esxi:ha-datacenter/datastore1/web/web_1.vmdk"},
+					"scsi0":    {Volume: "esxi:ha-datacenter/datastore1/web/web.vmdk"},
+					"virtio2":  {Volume: "esxi:ha-datacenter/datastore1/web/web_2.vmdk"}},
+				MemoryMiB: 8192,
+				Name:      "web",
+				Networks: map[QemuNetworkInterfaceID]ImportMetadataNetwork{
+					0: {MacAddress: "00:50:56:aa:bb:cc", Model: "vmxnet3"},
+					1: {Model: "e1000"}},
+				OsType:         "win11",
+				ScsiController: "pvscsi",
+				Sockets:        2},
+			target: target,
+			output: &ConfigQemu{
+				Bios: "ovmf",
+				Boot: "order=scsi0;net0",
+				CPU:  &QemuCPU{Cores: util.Pointer(QemuCpuCores(4)), Sockets: util.Pointer(QemuCpuSockets(2))},
+				Disks: &QemuStorages{
+					Ide: &QemuIdeDisks{Disk_3: &QemuIdeStorage{Disk: &QemuIdeDisk{
+						Backup: true, Format: QemuDiskFormat_Raw, ImportFrom: util.Pointer(importDisk("esxi:ha-datacenter/datastore1/web/web_3.vmdk")), Replicate: true, Storage: "ceph"}}},
+					Sata: &QemuSataDisks{Disk_1: &QemuSataStorage{Disk: &QemuSataDisk{
+						Backup: true, Format: QemuDiskFormat_Raw, ImportFrom: util.Pointer(importDisk("esxi:ha-datacenter/datastore1/web/web_1.vmdk")), Replicate: true, Storage: "ceph"}}},
+					Scsi: &QemuScsiDisks{Disk_0: &QemuScsiStorage{Disk: &QemuScsiDisk{
+						Backup: true, Format: QemuDiskFormat_Raw, ImportFrom: util.Pointer(importDisk("esxi:ha-datacenter/datastore1/web/web.vmdk")), Replicate: true, Storage: "ceph"}}},
+					VirtIO: &QemuVirtIODisks{Disk_2: &QemuVirtIOStorage{Disk: &QemuVirtIODisk{
+						Backup: true, Format: QemuDiskFormat_Raw, ImportFrom: util.Pointer(importDisk("esxi:ha-datacenter/datastore1/web/web_2.vmdk")), Replicate: true, Storage: "ceph"}}}},
+				EFIDisk: QemuDevice{"storage": "ceph", "efitype": "4m"},
+				Memory:  &QemuMemory{CapacityMiB: util.Pointer(QemuMemoryCapacity(8192))},
+				Name:    "web",
+				QemuNetworks: QemuDevices{
+					0: QemuDevice{"bridge": "vmbr1", "macaddr": "00:50:56:aa:bb:cc", "model": "vmxnet3"},
+					1: QemuDevice{"bridge": "vmbr1", "model": "e1000"}},
+				QemuOs: "win11",
+				Scsihw: "pvscsi"}},
+		{name: `Minimal`,
+			input:  ImportMetadata{Name: "empty"},
+			target: target,
+			output: &ConfigQemu{Name: "empty"}},
+		{name: `Invalid target`,
+			input: ImportMetadata{Name: "web"},
+			err:   errors.New(ImportMetadataTarget_Error_Bridge)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := test.input.ConfigQemu(test.target)
+			require.Equal(t, test.err, err)
+			require.Equal(t, test.output, config)
+		})
+	}
+}
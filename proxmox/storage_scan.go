@@ -0,0 +1,443 @@
+package proxmox
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Export of an NFS server.
+type StorageScan_NFS struct {
+	Export  string `json:"export"`
+	Options string `json:"options,omitempty"` // clients that are allowed to mount the export
+}
+
+func (StorageScan_NFS) mapToSDK(params map[string]interface{}) (scan StorageScan_NFS) {
+	if v, isSet := params["path"]; isSet {
+		scan.Export = v.(string)
+	}
+	if v, isSet := params["options"]; isSet {
+		scan.Options = v.(string)
+	}
+	return
+}
+
+// Share of an SMB/CIFS server.
+type StorageScan_SMB struct {
+	Description string `json:"description,omitempty"`
+	Share       string `json:"share"`
+}
+
+func (StorageScan_SMB) mapToSDK(params map[string]interface{}) (scan StorageScan_SMB) {
+	if v, isSet := params["description"]; isSet {
+		scan.Description = v.(string)
+	}
+	if v, isSet := params["share"]; isSet {
+		scan.Share = v.(string)
+	}
+	return
+}
+
+// Target of an iSCSI portal.
+type StorageScan_ISCSI struct {
+	Portal string `json:"portal"`
+	Target string `json:"target"`
+}
+
+func (StorageScan_ISCSI) mapToSDK(params map[string]interface{}) (scan StorageScan_ISCSI) {
+	if v, isSet := params["portal"]; isSet {
+		scan.Portal = v.(string)
+	}
+	if v, isSet := params["target"]; isSet {
+		scan.Target = v.(string)
+	}
+	return
+}
+
+// LVM volume group on a node.
+type StorageScan_LVM struct {
+	FreeBytes uint   `json:"free"`
+	SizeBytes uint   `json:"size"`
+	VGname    string `json:"vgname"`
+}
+
+func (StorageScan_LVM) mapToSDK(params map[string]interface{}) (scan StorageScan_LVM) {
+	if v, isSet := params["free"]; isSet {
+		scan.FreeBytes = uint(v.(float64))
+	}
+	if v, isSet := params["size"]; isSet {
+		scan.SizeBytes = uint(v.(float64))
+	}
+	if v, isSet := params["vg"]; isSet {
+		scan.VGname = v.(string)
+	}
+	return
+}
+
+// LVM thin pool in a volume group on a node.
+type StorageScan_LVMThin struct {
+	MetadataSizeBytes uint   `json:"metadata_size"`
+	MetadataUsedBytes uint   `json:"metadata_used"`
+	SizeBytes         uint   `json:"size"`
+	Thinpool          string `json:"thinpool"`
+	UsedBytes         uint   `json:"used"`
+}
+
+func (StorageScan_LVMThin) mapToSDK(params map[string]interface{}) (scan StorageScan_LVMThin) {
+	if v, isSet := params["lv"]; isSet {
+		scan.Thinpool = v.(string)
+	}
+	if v, isSet := params["lv_size"]; isSet {
+		scan.SizeBytes = uint(v.(float64))
+	}
+	if v, isSet := params["metadata_size"]; isSet {
+		scan.MetadataSizeBytes = uint(v.(float64))
+	}
+	if v, isSet := params["metadata_used"]; isSet {
+		scan.MetadataUsedBytes = uint(v.(float64))
+	}
+	if v, isSet := params["used"]; isSet {
+		scan.UsedBytes = uint(v.(float64))
+	}
+	return
+}
+
+// ZFS pool or dataset on a node.
+type StorageScan_ZFS struct {
+	FreeBytes uint   `json:"free"`
+	Pool      string `json:"pool"` // e.g. "rpool" or "rpool/data"
+	SizeBytes uint   `json:"size"`
+}
+
+func (StorageScan_ZFS) mapToSDK(params map[string]interface{}) (scan StorageScan_ZFS) {
+	if v, isSet := params["free"]; isSet {
+		scan.FreeBytes = uint(v.(float64))
+	}
+	if v, isSet := params["pool"]; isSet {
+		scan.Pool = v.(string)
+	}
+	if v, isSet := params["size"]; isSet {
+		scan.SizeBytes = uint(v.(float64))
+	}
+	return
+}
+
+// Datastore of a Proxmox Backup Server.
+type StorageScan_PBS struct {
+	Comment   string `json:"comment,omitempty"`
+	Datastore string `json:"datastore"`
+}
+
+func (StorageScan_PBS) mapToSDK(params map[string]interface{}) (scan StorageScan_PBS) {
+	if v, isSet := params["comment"]; isSet {
+		scan.Comment = v.(string)
+	}
+	if v, isSet := params["store"]; isSet {
+		scan.Datastore = v.(string)
+	}
+	return
+}
+
+// Volume of a GlusterFS server.
+type StorageScan_GlusterFS struct {
+	Volume string `json:"volume"`
+}
+
+func (StorageScan_GlusterFS) mapToSDK(params map[string]interface{}) (scan StorageScan_GlusterFS) {
+	if v, isSet := params["volname"]; isSet {
+		scan.Volume = v.(string)
+	}
+	return
+}
+
+// Lists the exports of the NFS server, as seen by the node.
+func ScanStorageNFS(c *Client, node, server string) ([]StorageScan_NFS, error) {
+	list, err := storageScan(c, node, "nfs", url.Values{"server": []string{server}})
+	if err != nil {
+		return nil, err
+	}
+	scans := make([]StorageScan_NFS, len(list))
+	for i, e := range list {
+		scans[i] = StorageScan_NFS{}.mapToSDK(e)
+	}
+	return scans, nil
+}
+
+// Lists the shares of the SMB/CIFS server, as seen by the node. The credentials are optional.
+func ScanStorageSMB(c *Client, node, server, username, password, domain string) ([]StorageScan_SMB, error) {
+	params := url.Values{"server": []string{server}}
+	if username != "" {
+		params.Set("username", username)
+	}
+	if password != "" {
+		params.Set("password", password)
+	}
+	if domain != "" {
+		params.Set("domain", domain)
+	}
+	list, err := storageScan(c, node, "cifs", params)
+	if err != nil {
+		return nil, err
+	}
+	scans := make([]StorageScan_SMB, len(list))
+	for i, e := range list {
+		scans[i] = StorageScan_SMB{}.mapToSDK(e)
+	}
+	return scans, nil
+}
+
+// Lists the targets of the iSCSI portal, as seen by the node.
+func ScanStorageISCSI(c *Client, node, portal string) ([]StorageScan_ISCSI, error) {
+	list, err := storageScan(c, node, "iscsi", url.Values{"portal": []string{portal}})
+	if err != nil {
+		return nil, err
+	}
+	scans := make([]StorageScan_ISCSI, len(list))
+	for i, e := range list {
+		scans[i] = StorageScan_ISCSI{}.mapToSDK(e)
+	}
+	return scans, nil
+}
+
+// Lists the LVM volume groups on the node.
+func ScanStorageLVM(c *Client, node string) ([]StorageScan_LVM, error) {
+	list, err := storageScan(c, node, "lvm", nil)
+	if err != nil {
+		return nil, err
+	}
+	scans := make([]StorageScan_LVM, len(list))
+	for i, e := range list {
+		scans[i] = StorageScan_LVM{}.mapToSDK(e)
+	}
+	return scans, nil
+}
+
+// Lists the LVM thin pools in the volume group on the node.
+func ScanStorageLVMThin(c *Client, node, vgname string) ([]StorageScan_LVMThin, error) {
+	list, err := storageScan(c, node, "lvmthin", url.Values{"vg": []string{vgname}})
+	if err != nil {
+		return nil, err
+	}
+	scans := make([]StorageScan_LVMThin, len(list))
+	for i, e := range list {
+		scans[i] = StorageScan_LVMThin{}.mapToSDK(e)
+	}
+	return scans, nil
+}
+
+// Lists the ZFS pools and datasets on the node.
+func ScanStorageZFS(c *Client, node string) ([]StorageScan_ZFS, error) {
+	list, err := storageScan(c, node, "zfs", nil)
+	if err != nil {
+		return nil, err
+	}
+	scans := make([]StorageScan_ZFS, len(list))
+	for i, e := range list {
+		scans[i] = StorageScan_ZFS{}.mapToSDK(e)
+	}
+	return scans, nil
+}
+
+// Lists the datastores of the Proxmox Backup Server, as seen by the node.
+// The fingerprint is only required when the certificate of the server is not trusted, port 0 uses the default port.
+func ScanStoragePBS(c *Client, node, server, username, password, fingerprint string, port uint) ([]StorageScan_PBS, error) {
+	params := url.Values{
+		"server":   []string{server},
+		"username": []string{username},
+		"password": []string{password},
+	}
+	if fingerprint != "" {
+		params.Set("fingerprint", fingerprint)
+	}
+	if port != 0 {
+		params.Set("port", strconv.FormatUint(uint64(port), 10))
+	}
+	list, err := storageScan(c, node, "pbs", params)
+	if err != nil {
+		return nil, err
+	}
+	scans := make([]StorageScan_PBS, len(list))
+	for i, e := range list {
+		scans[i] = StorageScan_PBS{}.mapToSDK(e)
+	}
+	return scans, nil
+}
+
+// Lists the volumes of the GlusterFS server, as seen by the node.
+func ScanStorageGlusterFS(c *Client, node, server string) ([]StorageScan_GlusterFS, error) {
+	list, err := storageScan(c, node, "glusterfs", url.Values{"server": []string{server}})
+	if err != nil {
+		return nil, err
+	}
+	scans := make([]StorageScan_GlusterFS, len(list))
+	for i, e := range list {
+		scans[i] = StorageScan_GlusterFS{}.mapToSDK(e)
+	}
+	return scans, nil
+}
+
+// https://pve.proxmox.com/pve-docs/api-viewer/#/nodes/{node}/scan
+func storageScan(c *Client, node, backend string, params url.Values) ([]map[string]interface{}, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	query := ""
+	if len(params) > 0 {
+		query = "?" + params.Encode()
+	}
+	list, err := c.GetItemListInterfaceArray("/nodes/" + node + "/scan/" + backend + query)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]map[string]interface{}, len(list))
+	for i, e := range list {
+		items[i] = e.(map[string]interface{})
+	}
+	return items, nil
+}
+
+// Validates the config the same way as Validate, when the storage is created it also checks the backend on the node with ValidateBackend.
+func (config *ConfigStorage) ValidateWithBackend(id string, create bool, node string, client *Client) error {
+	if err := config.Validate(id, create, client); err != nil {
+		return err
+	}
+	if !create {
+		return nil
+	}
+	return config.ValidateBackend(node, client)
+}
+
+// Checks on the node that the export, share, target, volume group, thin pool, pool, datastore or volume referenced by the storage exists.
+// Storage types that can not be scanned are not checked.
+func (config *ConfigStorage) ValidateBackend(node string, client *Client) error {
+	var scans interface{}
+	var err error
+	switch config.Type {
+	case "nfs":
+		if config.NFS == nil {
+			return nil
+		}
+		scans, err = ScanStorageNFS(client, node, config.NFS.Server)
+	case "smb":
+		if config.SMB == nil {
+			return nil
+		}
+		var password string
+		if config.SMB.Password != nil {
+			password = *config.SMB.Password
+		}
+		scans, err = ScanStorageSMB(client, node, config.SMB.Server, config.SMB.Username, password, config.SMB.Domain)
+	case "iscsi":
+		if config.ISCSI == nil {
+			return nil
+		}
+		scans, err = ScanStorageISCSI(client, node, config.ISCSI.Portal)
+	case "lvm":
+		if config.LVM == nil {
+			return nil
+		}
+		scans, err = ScanStorageLVM(client, node)
+	case "lvm-thin":
+		if config.LVMThin == nil {
+			return nil
+		}
+		scans, err = ScanStorageLVMThin(client, node, config.LVMThin.VGname)
+	case "zfs":
+		if config.ZFS == nil {
+			return nil
+		}
+		scans, err = ScanStorageZFS(client, node)
+	case "pbs":
+		if config.PBS == nil {
+			return nil
+		}
+		var password string
+		if config.PBS.Password != nil {
+			password = *config.PBS.Password
+		}
+		var port uint
+		if config.PBS.Port != nil {
+			port = uint(*config.PBS.Port)
+		}
+		scans, err = ScanStoragePBS(client, node, config.PBS.Server, config.PBS.Username, password, config.PBS.Fingerprint, port)
+	case "glusterfs":
+		if config.GlusterFS == nil {
+			return nil
+		}
+		scans, err = ScanStorageGlusterFS(client, node, config.GlusterFS.Server1)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return config.validateBackendScan(scans)
+}
+
+// Checks that the backend referenced by the storage is in the scan result of its type.
+func (config *ConfigStorage) validateBackendScan(scans interface{}) error {
+	switch scans := scans.(type) {
+	case []StorageScan_NFS:
+		for _, e := range scans {
+			if e.Export == config.NFS.Export {
+				return nil
+			}
+		}
+		return errorStorageScanNotFound(config.NFS.Export, "nfs:{ export }")
+	case []StorageScan_SMB:
+		for _, e := range scans {
+			if e.Share == config.SMB.Share {
+				return nil
+			}
+		}
+		return errorStorageScanNotFound(config.SMB.Share, "smb:{ share }")
+	case []StorageScan_ISCSI:
+		for _, e := range scans {
+			if e.Target == config.ISCSI.Target {
+				return nil
+			}
+		}
+		return errorStorageScanNotFound(config.ISCSI.Target, "iscsi:{ target }")
+	case []StorageScan_LVM:
+		for _, e := range scans {
+			if e.VGname == config.LVM.VGname {
+				return nil
+			}
+		}
+		return errorStorageScanNotFound(config.LVM.VGname, "lvm:{ vgname }")
+	case []StorageScan_LVMThin:
+		for _, e := range scans {
+			if e.Thinpool == config.LVMThin.Thinpool {
+				return nil
+			}
+		}
+		return errorStorageScanNotFound(config.LVMThin.Thinpool, "lvm-thin:{ thinpool }")
+	case []StorageScan_ZFS:
+		for _, e := range scans {
+			if e.Pool == config.ZFS.Pool {
+				return nil
+			}
+		}
+		return errorStorageScanNotFound(config.ZFS.Pool, "zfs:{ pool }")
+	case []StorageScan_PBS:
+		for _, e := range scans {
+			if e.Datastore == config.PBS.Datastore {
+				return nil
+			}
+		}
+		return errorStorageScanNotFound(config.PBS.Datastore, "pbs:{ datastore }")
+	case []StorageScan_GlusterFS:
+		for _, e := range scans {
+			if e.Volume == config.GlusterFS.Volume {
+				return nil
+			}
+		}
+		return errorStorageScanNotFound(config.GlusterFS.Volume, "glusterfs:{ volume }")
+	}
+	return nil
+}
+
+func errorStorageScanNotFound(value, text string) error {
+	return fmt.Errorf("error the value of key (%s) ( %s ) was not found on the storage backend", text, value)
+}
@@ -0,0 +1,132 @@
+package proxmox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StorageScan_mapToSDK(t *testing.T) {
+	require.Equal(t,
+		StorageScan_NFS{Export: "/mnt/backup", Options: "10.0.0.0/24"},
+		StorageScan_NFS{}.mapToSDK(map[string]interface{}{"path": "/mnt/backup", "options": "10.0.0.0/24"}))
+	require.Equal(t,
+		StorageScan_SMB{Description: "ISO images", Share: "iso"},
+		StorageScan_SMB{}.mapToSDK(map[string]interface{}{"description": "ISO images", "share": "iso"}))
+	require.Equal(t,
+		StorageScan_ISCSI{Portal: "10.0.0.5:3260", Target: "iqn.2003-01.org.linux-iscsi.san:sn.1"},
+		StorageScan_ISCSI{}.mapToSDK(map[string]interface{}{"portal": "10.0.0.5:3260", "target": "iqn.2003-01.org.linux-iscsi.san:sn.1"}))
+	require.Equal(t,
+		StorageScan_LVM{FreeBytes: 1024, SizeBytes: 4096, VGname: "pve"},
+		StorageScan_LVM{}.mapToSDK(map[string]interface{}{"free": float64(1024), "size": float64(4096), "vg": "pve"}))
+	require.Equal(t,
+		StorageScan_LVMThin{MetadataSizeBytes: 64, MetadataUsedBytes: 8, SizeBytes: 4096, Thinpool: "data", UsedBytes: 512},
+		StorageScan_LVMThin{}.mapToSDK(map[string]interface{}{
+			"lv":            "data",
+			"lv_size":       float64(4096),
+			"metadata_size": float64(64),
+			"metadata_used": float64(8),
+			"used":          float64(512)}))
+	require.Equal(t,
+		StorageScan_ZFS{FreeBytes: 100, Pool: "rpool/data", SizeBytes: 200},
+		StorageScan_ZFS{}.mapToSDK(map[string]interface{}{"free": float64(100), "pool": "rpool/data", "size": float64(200)}))
+	require.Equal(t,
+		StorageScan_PBS{Comment: "offsite", Datastore: "store1"},
+		StorageScan_PBS{}.mapToSDK(map[string]interface{}{"comment": "offsite", "store": "store1"}))
+	require.Equal(t,
+		StorageScan_GlusterFS{Volume: "gv0"},
+		StorageScan_GlusterFS{}.mapToSDK(map[string]interface{}{"volname": "gv0"}))
+}
+
+func Test_ConfigStorage_ValidateBackend(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  ConfigStorage
+		output error
+	}{
+		{name: `Not scanned type`,
+			input: ConfigStorage{Type: "directory", Directory: &ConfigStorageDirectory{Path: "/mnt"}}},
+		{name: `Backend not set`,
+			input: ConfigStorage{Type: "nfs"}},
+		{name: `Nil client`,
+			input:  ConfigStorage{Type: "zfs", ZFS: &ConfigStorageZFS{Pool: "rpool"}},
+			output: errors.New(Client_Error_Nil)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.input.ValidateBackend("pve", nil))
+		})
+	}
+}
+
+func Test_ConfigStorage_validateBackendScan(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ConfigStorage
+		scans  interface{}
+		output error
+	}{
+		{name: `NFS found`,
+			config: ConfigStorage{NFS: &ConfigStorageNFS{Export: "/mnt/backup"}},
+			scans:  []StorageScan_NFS{{Export: "/mnt/images"}, {Export: "/mnt/backup"}}},
+		{name: `NFS not found`,
+			config: ConfigStorage{NFS: &ConfigStorageNFS{Export: "/mnt/backup"}},
+			scans:  []StorageScan_NFS{{Export: "/mnt/images"}},
+			output: errorStorageScanNotFound("/mnt/backup", "nfs:{ export }")},
+		{name: `SMB found`,
+			config: ConfigStorage{SMB: &ConfigStorageSMB{Share: "iso"}},
+			scans:  []StorageScan_SMB{{Share: "iso"}}},
+		{name: `SMB not found`,
+			config: ConfigStorage{SMB: &ConfigStorageSMB{Share: "iso"}},
+			scans:  []StorageScan_SMB{{Share: "backup"}},
+			output: errorStorageScanNotFound("iso", "smb:{ share }")},
+		{name: `ISCSI found`,
+			config: ConfigStorage{ISCSI: &ConfigStorageISCSI{Target: "iqn.2003-01.org.linux-iscsi.san:sn.1"}},
+			scans:  []StorageScan_ISCSI{{Target: "iqn.2003-01.org.linux-iscsi.san:sn.1"}}},
+		{name: `ISCSI not found`,
+			config: ConfigStorage{ISCSI: &ConfigStorageISCSI{Target: "iqn.2003-01.org.linux-iscsi.san:sn.1"}},
+			scans:  []StorageScan_ISCSI{},
+			output: errorStorageScanNotFound("iqn.2003-01.org.linux-iscsi.san:sn.1", "iscsi:{ target }")},
+		{name: `LVM found`,
+			config: ConfigStorage{LVM: &ConfigStorageLVM{VGname: "pve"}},
+			scans:  []StorageScan_LVM{{VGname: "pve"}}},
+		{name: `LVM not found`,
+			config: ConfigStorage{LVM: &ConfigStorageLVM{VGname: "data"}},
+			scans:  []StorageScan_LVM{{VGname: "pve"}},
+			output: errorStorageScanNotFound("data", "lvm:{ vgname }")},
+		{name: `LVM-Thin found`,
+			config: ConfigStorage{LVMThin: &ConfigStorageLVMThin{VGname: "pve", Thinpool: "data"}},
+			scans:  []StorageScan_LVMThin{{Thinpool: "data"}}},
+		{name: `LVM-Thin not found`,
+			config: ConfigStorage{LVMThin: &ConfigStorageLVMThin{VGname: "pve", Thinpool: "data"}},
+			scans:  []StorageScan_LVMThin{{Thinpool: "vmstore"}},
+			output: errorStorageScanNotFound("data", "lvm-thin:{ thinpool }")},
+		{name: `ZFS found`,
+			config: ConfigStorage{ZFS: &ConfigStorageZFS{Pool: "rpool"}},
+			scans:  []StorageScan_ZFS{{Pool: "rpool"}, {Pool: "rpool/data"}}},
+		{name: `ZFS not found`,
+			config: ConfigStorage{ZFS: &ConfigStorageZFS{Pool: "tank"}},
+			scans:  []StorageScan_ZFS{{Pool: "rpool"}},
+			output: errorStorageScanNotFound("tank", "zfs:{ pool }")},
+		{name: `PBS found`,
+			config: ConfigStorage{PBS: &ConfigStoragePBS{Datastore: "store1"}},
+			scans:  []StorageScan_PBS{{Datastore: "store1"}}},
+		{name: `PBS not found`,
+			config: ConfigStorage{PBS: &ConfigStoragePBS{Datastore: "store2"}},
+			scans:  []StorageScan_PBS{{Datastore: "store1"}},
+			output: errorStorageScanNotFound("store2", "pbs:{ datastore }")},
+		{name: `GlusterFS found`,
+			config: ConfigStorage{GlusterFS: &ConfigStorageGlusterFS{Volume: "gv0"}},
+			scans:  []StorageScan_GlusterFS{{Volume: "gv0"}}},
+		{name: `GlusterFS not found`,
+			config: ConfigStorage{GlusterFS: &ConfigStorageGlusterFS{Volume: "gv1"}},
+			scans:  []StorageScan_GlusterFS{{Volume: "gv0"}},
+			output: errorStorageScanNotFound("gv1", "glusterfs:{ volume }")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, test.config.validateBackendScan(test.scans))
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package proxmox
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/Telmate/proxmox-api-go/sizeunit"
+)
+
+// Status and capacity of a storage as seen by a node.
+type StorageStatus struct {
+	Active         bool          `json:"active"`
+	AvailableBytes uint          `json:"available"`
+	Content        []ContentType `json:"content"`
+	Enabled        bool          `json:"enabled"`
+	Name           string        `json:"name"`
+	Node           string        `json:"node"`
+	Shared         bool          `json:"shared"`
+	TotalBytes     uint          `json:"total"`
+	Type           string        `json:"type"`
+	UsedBytes      uint          `json:"used"`
+}
+
+const StorageStatus_Error_NotFound string = "storage does not exist on node: "
+
+// Maps the status returned by /nodes/{node}/storage.
+func (StorageStatus) mapToSDK(params map[string]interface{}) (status StorageStatus) {
+	if v, isSet := params["active"]; isSet {
+		status.Active = apiBool(v)
+	}
+	if v, isSet := params["avail"]; isSet {
+		status.AvailableBytes = uint(v.(float64))
+	}
+	if v, isSet := params["content"]; isSet {
+		status.Content = storageStatusContent(v.(string))
+	}
+	if v, isSet := params["enabled"]; isSet {
+		status.Enabled = apiBool(v)
+	}
+	if v, isSet := params["shared"]; isSet {
+		status.Shared = apiBool(v)
+	}
+	if v, isSet := params["storage"]; isSet {
+		status.Name = v.(string)
+	}
+	if v, isSet := params["total"]; isSet {
+		status.TotalBytes = uint(v.(float64))
+	}
+	if v, isSet := params["type"]; isSet {
+		status.Type = v.(string)
+	}
+	if v, isSet := params["used"]; isSet {
+		status.UsedBytes = uint(v.(float64))
+	}
+	return
+}
+
+// Maps the status returned by /cluster/resources?type=storage.
+// The cluster resources do not report whether a storage is enabled, so Enabled is the same as Active.
+func (StorageStatus) mapToSdkFromResource(params map[string]interface{}) (status StorageStatus) {
+	if v, isSet := params["content"]; isSet {
+		status.Content = storageStatusContent(v.(string))
+	}
+	if v, isSet := params["disk"]; isSet {
+		status.UsedBytes = uint(v.(float64))
+	}
+	if v, isSet := params["maxdisk"]; isSet {
+		status.TotalBytes = uint(v.(float64))
+	}
+	if v, isSet := params["node"]; isSet {
+		status.Node = v.(string)
+	}
+	if v, isSet := params["plugintype"]; isSet {
+		status.Type = v.(string)
+	}
+	if v, isSet := params["shared"]; isSet {
+		status.Shared = apiBool(v)
+	}
+	if v, isSet := params["status"]; isSet {
+		status.Active = v.(string) == "available"
+		status.Enabled = status.Active
+	}
+	if v, isSet := params["storage"]; isSet {
+		status.Name = v.(string)
+	}
+	if status.TotalBytes > status.UsedBytes {
+		status.AvailableBytes = status.TotalBytes - status.UsedBytes
+	}
+	return
+}
+
+func storageStatusContent(content string) []ContentType {
+	contentTypes := make([]ContentType, 0)
+	for _, e := range strings.Split(content, ",") {
+		if e != "" {
+			contentTypes = append(contentTypes, ContentType("").fromApiValue(e))
+		}
+	}
+	return contentTypes
+}
+
+// Returns the available capacity in the given unit, rounded down.
+func (status StorageStatus) Available(unit sizeunit.SizeUnit) int {
+	size, _ := sizeunit.ConvertTo(int(status.AvailableBytes), sizeunit.B, unit)
+	return size
+}
+
+// Returns the total capacity in the given unit, rounded down.
+func (status StorageStatus) Total(unit sizeunit.SizeUnit) int {
+	size, _ := sizeunit.ConvertTo(int(status.TotalBytes), sizeunit.B, unit)
+	return size
+}
+
+// Returns the used capacity in the given unit, rounded down.
+func (status StorageStatus) Used(unit sizeunit.SizeUnit) int {
+	size, _ := sizeunit.ConvertTo(int(status.UsedBytes), sizeunit.B, unit)
+	return size
+}
+
+// Returns the status of the storage on the node.
+func NewStorageStatusFromApi(c *Client, node, storage string) (*StorageStatus, error) {
+	statuses, err := ListStorageStatuses(c, node)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range statuses {
+		if e.Name == storage {
+			return &e, nil
+		}
+	}
+	return nil, errors.New(StorageStatus_Error_NotFound + storage)
+}
+
+// Returns the status of all storages on the node, sorted by name.
+func ListStorageStatuses(c *Client, node string) ([]StorageStatus, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	list, err := c.GetItemListInterfaceArray("/nodes/" + node + "/storage")
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]StorageStatus, len(list))
+	for i, e := range list {
+		statuses[i] = StorageStatus{}.mapToSDK(e.(map[string]interface{}))
+		statuses[i].Node = node
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses, nil
+}
+
+// Returns the status of all storages on all nodes, sorted by node and name.
+// Shared storages are returned once for every node.
+func ListStorageStatusesCluster(c *Client) ([]StorageStatus, error) {
+	if c == nil {
+		return nil, errors.New(Client_Error_Nil)
+	}
+	list, err := c.GetResourceList("storage")
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]StorageStatus, len(list))
+	for i, e := range list {
+		statuses[i] = StorageStatus{}.mapToSdkFromResource(e.(map[string]interface{}))
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Node != statuses[j].Node {
+			return statuses[i].Node < statuses[j].Node
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses, nil
+}
+
+// Aggregate capacity of a set of storages.
+type StorageCapacity struct {
+	AvailableBytes uint `json:"available"`
+	Storages       uint `json:"storages"` // number of storages included
+	TotalBytes     uint `json:"total"`
+	UsedBytes      uint `json:"used"`
+}
+
+func (capacity *StorageCapacity) add(status StorageStatus) {
+	capacity.AvailableBytes += status.AvailableBytes
+	capacity.Storages++
+	capacity.TotalBytes += status.TotalBytes
+	capacity.UsedBytes += status.UsedBytes
+}
+
+// Returns the available capacity in the given unit, rounded down.
+func (capacity StorageCapacity) Available(unit sizeunit.SizeUnit) int {
+	size, _ := sizeunit.ConvertTo(int(capacity.AvailableBytes), sizeunit.B, unit)
+	return size
+}
+
+// Returns the total capacity in the given unit, rounded down.
+func (capacity StorageCapacity) Total(unit sizeunit.SizeUnit) int {
+	size, _ := sizeunit.ConvertTo(int(capacity.TotalBytes), sizeunit.B, unit)
+	return size
+}
+
+// Returns the used capacity in the given unit, rounded down.
+func (capacity StorageCapacity) Used(unit sizeunit.SizeUnit) int {
+	size, _ := sizeunit.ConvertTo(int(capacity.UsedBytes), sizeunit.B, unit)
+	return size
+}
+
+type StorageCapacitySummary struct {
+	Local  StorageCapacity `json:"local"`
+	Shared StorageCapacity `json:"shared"`
+}
+
+// Sums the capacity of the active storages, separately for shared and local storages.
+// Shared storages are only counted once, even when they are reported by multiple nodes.
+func SummarizeStorageCapacity(statuses []StorageStatus) StorageCapacitySummary {
+	var summary StorageCapacitySummary
+	shared := make(map[string]struct{})
+	for _, e := range statuses {
+		if !e.Active {
+			continue
+		}
+		if !e.Shared {
+			summary.Local.add(e)
+			continue
+		}
+		if _, isSet := shared[e.Name]; isSet {
+			continue
+		}
+		shared[e.Name] = struct{}{}
+		summary.Shared.add(e)
+	}
+	return summary
+}
@@ -0,0 +1,108 @@
+package proxmox
+
+import (
+	"testing"
+
+	"github.com/Telmate/proxmox-api-go/sizeunit"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StorageStatus_mapToSDK(t *testing.T) {
+	require.Equal(t,
+		StorageStatus{
+			Active:         true,
+			AvailableBytes: 75 * 1073741824,
+			Content:        []ContentType{ContentType_Backup, ContentType_Iso, ContentType_Template, ContentType_Import},
+			Enabled:        true,
+			Name:           "local",
+			Shared:         false,
+			TotalBytes:     100 * 1073741824,
+			Type:           "dir",
+			UsedBytes:      25 * 1073741824},
+		StorageStatus{}.mapToSDK(map[string]interface{}{
+			"active":  float64(1),
+			"avail":   float64(75 * 1073741824),
+			"content": "backup,iso,vztmpl,import",
+			"enabled": float64(1),
+			"shared":  float64(0),
+			"storage": "local",
+			"total":   float64(100 * 1073741824),
+			"type":    "dir",
+			"used":    float64(25 * 1073741824)}))
+}
+
+func Test_StorageStatus_mapToSdkFromResource(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  map[string]interface{}
+		output StorageStatus
+	}{
+		{name: `Available`,
+			input: map[string]interface{}{
+				"content":    "images,rootdir",
+				"disk":       float64(400),
+				"id":         "storage/pve1/ceph",
+				"maxdisk":    float64(1000),
+				"node":       "pve1",
+				"plugintype": "rbd",
+				"shared":     float64(1),
+				"status":     "available",
+				"storage":    "ceph",
+				"type":       "storage"},
+			output: StorageStatus{
+				Active:         true,
+				AvailableBytes: 600,
+				Content:        []ContentType{ContentType_DiskImage, ContentType_Container},
+				Enabled:        true,
+				Name:           "ceph",
+				Node:           "pve1",
+				Shared:         true,
+				TotalBytes:     1000,
+				Type:           "rbd",
+				UsedBytes:      400}},
+		{name: `Unknown`,
+			input: map[string]interface{}{
+				"content":    "backup",
+				"node":       "pve2",
+				"plugintype": "nfs",
+				"shared":     float64(1),
+				"status":     "unknown",
+				"storage":    "nfs"},
+			output: StorageStatus{
+				Content: []ContentType{ContentType_Backup},
+				Name:    "nfs",
+				Node:    "pve2",
+				Shared:  true,
+				Type:    "nfs"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.output, StorageStatus{}.mapToSdkFromResource(test.input))
+		})
+	}
+}
+
+func Test_StorageStatus_units(t *testing.T) {
+	status := StorageStatus{TotalBytes: 2 * 1099511627776, UsedBytes: 1536 * 1048576, AvailableBytes: 3*1073741824 + 1}
+	require.Equal(t, 2, status.Total(sizeunit.TB))
+	require.Equal(t, 2048, status.Total(sizeunit.GB))
+	require.Equal(t, 1, status.Used(sizeunit.GB))
+	require.Equal(t, 1536, status.Used(sizeunit.MB))
+	require.Equal(t, 3, status.Available(sizeunit.GB))
+	require.Equal(t, 3*1073741824+1, status.Available(sizeunit.B))
+}
+
+func Test_SummarizeStorageCapacity(t *testing.T) {
+	statuses := []StorageStatus{
+		{Name: "ceph", Node: "pve1", Active: true, Shared: true, TotalBytes: 1000, UsedBytes: 400, AvailableBytes: 600},
+		{Name: "ceph", Node: "pve2", Active: true, Shared: true, TotalBytes: 1000, UsedBytes: 400, AvailableBytes: 600},
+		{Name: "nfs", Node: "pve1", Active: true, Shared: true, TotalBytes: 500, UsedBytes: 100, AvailableBytes: 400},
+		{Name: "nfs-offline", Node: "pve1", Shared: true, TotalBytes: 500},
+		{Name: "local", Node: "pve1", Active: true, TotalBytes: 100, UsedBytes: 10, AvailableBytes: 90},
+		{Name: "local", Node: "pve2", Active: true, TotalBytes: 200, UsedBytes: 20, AvailableBytes: 180},
+	}
+	require.Equal(t, StorageCapacitySummary{
+		Local:  StorageCapacity{AvailableBytes: 270, Storages: 2, TotalBytes: 300, UsedBytes: 30},
+		Shared: StorageCapacity{AvailableBytes: 1000, Storages: 2, TotalBytes: 1500, UsedBytes: 500},
+	}, SummarizeStorageCapacity(statuses))
+}
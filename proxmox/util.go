@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var rxUserTokenExtract = regexp.MustCompile("[a-z0-9]+@[a-z0-9]+!([a-z0-9]+)")
@@ -29,6 +30,20 @@ func Btoi(b bool) int {
 	}
 }
 
+// Converts a value of the api that may be a number, bool or string to a bool.
+func apiBool(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return false
+}
+
 func Itob(i int) bool {
 	return i == 1
 }
@@ -272,6 +287,25 @@ func subtractArray[T comparable](A, B []T) (result []T) {
 	return
 }
 
+// Calls condition every interval until it returns true or the next call would happen after the deadline.
+// Errors returned by condition are not fatal, the last one is returned when the deadline has passed.
+// When interval is 0 TaskStatusCheckInterval is used.
+func waitUntil(deadline time.Time, interval time.Duration, condition func() (bool, error)) (bool, error) {
+	if interval == 0 {
+		interval = TaskStatusCheckInterval * time.Second
+	}
+	for {
+		done, err := condition()
+		if done {
+			return true, nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return false, err
+		}
+		time.Sleep(interval)
+	}
+}
+
 // To be used during testing
 func uninitializedArray[T any]() []T {
 	var x []T
@@ -1,11 +1,22 @@
 package proxmox
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
+func Test_apiBool(t *testing.T) {
+	for _, e := range []interface{}{true, float64(1), float64(2), "1", "true"} {
+		require.True(t, apiBool(e), e)
+	}
+	for _, e := range []interface{}{nil, false, float64(0), "", "0", "false", "yes"} {
+		require.False(t, apiBool(e), e)
+	}
+}
+
 func Test_keyExists(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -139,3 +150,49 @@ func Test_subtractArray(t *testing.T) {
 		})
 	}
 }
+
+func Test_waitUntil(t *testing.T) {
+	tests := []struct {
+		name     string
+		deadline time.Duration
+		doneAt   int // call on which condition returns true, 0 is never
+		done     bool
+		err      error
+		calls    int // 0 when the number of calls depends on timing
+	}{
+		{name: `Done immediately`,
+			deadline: time.Second,
+			doneAt:   1,
+			done:     true,
+			calls:    1},
+		{name: `Done after retries`,
+			deadline: time.Second,
+			doneAt:   3,
+			done:     true,
+			calls:    3},
+		{name: `Deadline passed`,
+			deadline: 25 * time.Millisecond,
+			err:      errors.New("not ready")},
+		{name: `Deadline in the past`,
+			deadline: -time.Second,
+			err:      errors.New("not ready"),
+			calls:    1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls int
+			done, err := waitUntil(time.Now().Add(test.deadline), 10*time.Millisecond, func() (bool, error) {
+				calls++
+				if calls == test.doneAt {
+					return true, nil
+				}
+				return false, errors.New("not ready")
+			})
+			require.Equal(t, test.done, done)
+			require.Equal(t, test.err, err)
+			if test.calls != 0 {
+				require.Equal(t, test.calls, calls)
+			}
+		})
+	}
+}
@@ -7,22 +7,29 @@ import (
 
 type SizeUnit int64
 
+const B SizeUnit = 1
+
 const (
 	KB SizeUnit = 1 << (10 * (iota + 1))
 	MB
 	GB
+	TB
 )
 
 var shortUnitMap = map[SizeUnit]string{
+	B:  "B",
 	KB: "K",
 	MB: "M",
 	GB: "G",
+	TB: "T",
 }
 
 var longUnitMap = map[SizeUnit]string{
+	B:  "byte",
 	KB: "kilobyte",
 	MB: "megabyte",
 	GB: "gigabyte",
+	TB: "terabyte",
 }
 
 func FormatToShortString(size int, sizeUnit SizeUnit) string {